@@ -0,0 +1,155 @@
+// Package markdown builds Markdown formatted message bodies, escaping
+// user-supplied text so that it is rendered verbatim
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Text is a fragment of Markdown that is safe to embed in a message.
+// Fragments can be concatenated with the + operator
+type Text string
+
+// Builder assembles a Markdown document block by block
+type Builder struct {
+	blocks []string
+}
+
+// inlineSpecial are characters that have a meaning anywhere in a line
+const inlineSpecial = "\\`*_[]<>~|"
+
+// Escape returns s with all Markdown syntax escaped
+func Escape(s string) Text {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = escapeLine(line)
+	}
+
+	return Text(strings.Join(lines, "\n"))
+}
+
+// escapeLine escapes inline syntax as well as syntax that is only
+// meaningful at the start of a line, such as headings and list markers
+func escapeLine(line string) string {
+	var sb strings.Builder
+	for _, r := range line {
+		if strings.ContainsRune(inlineSpecial, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	escaped := sb.String()
+
+	trimmed := strings.TrimLeft(escaped, " \t")
+	indent := escaped[:len(escaped)-len(trimmed)]
+	if trimmed == "" {
+		return escaped
+	}
+
+	switch trimmed[0] {
+	case '#', '-', '+', '=', '>':
+		return indent + "\\" + trimmed
+	}
+
+	digits := len(trimmed) - len(strings.TrimLeft(trimmed, "0123456789"))
+	if digits > 0 && digits < len(trimmed) && (trimmed[digits] == '.' || trimmed[digits] == ')') {
+		return indent + trimmed[:digits] + "\\" + trimmed[digits:]
+	}
+
+	return escaped
+}
+
+// Bold returns s escaped and formatted in bold
+func Bold(s string) Text {
+	return "**" + Escape(s) + "**"
+}
+
+// Italic returns s escaped and formatted in italics
+func Italic(s string) Text {
+	return "_" + Escape(s) + "_"
+}
+
+// Code returns s formatted as inline code
+func Code(s string) Text {
+	fence := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+
+	return Text(fence + s + fence)
+}
+
+// Link returns a link with the given escaped label pointing to url
+func Link(label, url string) Text {
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(url)
+	return "[" + Escape(label) + "](" + Text(url) + ")"
+}
+
+// Heading adds a heading of the given level, between 1 and 6
+func (b *Builder) Heading(level int, text Text) *Builder {
+	level = max(1, min(level, 6))
+	text = Text(strings.ReplaceAll(string(text), "\n", " "))
+	b.blocks = append(b.blocks, strings.Repeat("#", level)+" "+string(text))
+	return b
+}
+
+// Paragraph adds a paragraph of text
+func (b *Builder) Paragraph(text Text) *Builder {
+	b.blocks = append(b.blocks, string(text))
+	return b
+}
+
+// List adds a bulleted list with the given items
+func (b *Builder) List(items ...Text) *Builder {
+	return b.list(items, func(int) string { return "- " })
+}
+
+// OrderedList adds a numbered list with the given items
+func (b *Builder) OrderedList(items ...Text) *Builder {
+	return b.list(items, func(i int) string { return strconv.Itoa(i+1) + ". " })
+}
+
+func (b *Builder) list(items []Text, marker func(int) string) *Builder {
+	if len(items) == 0 {
+		return b
+	}
+
+	lines := make([]string, len(items))
+	for i, item := range items {
+		m := marker(i)
+		// Continuation lines are indented to stay part of the list item
+		lines[i] = m + strings.ReplaceAll(string(item), "\n", "\n"+strings.Repeat(" ", len(m)))
+	}
+
+	b.blocks = append(b.blocks, strings.Join(lines, "\n"))
+	return b
+}
+
+// CodeBlock adds a fenced code block. The language may be empty
+func (b *Builder) CodeBlock(lang, code string) *Builder {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	code = strings.TrimSuffix(code, "\n")
+	b.blocks = append(b.blocks, fence+lang+"\n"+code+"\n"+fence)
+	return b
+}
+
+// String returns the Markdown document
+func (b *Builder) String() string {
+	return strings.Join(b.blocks, "\n\n")
+}
+
+// longestRun returns the length of the longest run of c in s
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+
+	return longest
+}
@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want Text
+	}{
+		{name: "Plain text", arg: "Deploy 1.5 finished", want: "Deploy 1.5 finished"},
+		{name: "Emphasis", arg: "*bold* _it_ `code`", want: "\\*bold\\* \\_it\\_ \\`code\\`"},
+		{name: "Link syntax", arg: "[x](y)", want: "\\[x\\](y)"},
+		{name: "Heading", arg: "# not a heading", want: "\\# not a heading"},
+		{name: "List marker", arg: "  - item", want: "  \\- item"},
+		{name: "Ordered list marker", arg: "12. item", want: "12\\. item"},
+		{name: "Multiple lines", arg: "a\n> quote", want: "a\n\\> quote"},
+		{name: "Backslash", arg: `C:\path`, want: `C:\\path`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Escape(tt.arg); got != tt.want {
+				t.Errorf("Escape() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Text
+		want Text
+	}{
+		{name: "Bold", got: Bold("a*b"), want: "**a\\*b**"},
+		{name: "Italic", got: Italic("a_b"), want: "_a\\_b_"},
+		{name: "Code", got: Code("x := 1"), want: "`x := 1`"},
+		{name: "Code with backticks", got: Code("`a``b"), want: "``` `a``b ```"},
+		{name: "Link", got: Link("the [docs]", "https://example.com/a b(1)"), want: "[the \\[docs\\]](https://example.com/a%20b%281%29)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	var b Builder
+	b.Heading(2, "Deploy "+Bold("v1.2")).
+		Paragraph(Escape("3 commits by ")+Link("alice", "https://example.com/alice")).
+		List(Code("abc123")+" "+Escape("fix *parser*"), Escape("multi\nline")).
+		OrderedList("one", "two").
+		CodeBlock("go", "fmt.Println(\"```\")\n").
+		Heading(9, "Too deep")
+
+	want := "## Deploy **v1.2**" + "\n\n" +
+		"3 commits by [alice](https://example.com/alice)" + "\n\n" +
+		"- `abc123` fix \\*parser\\*\n- multi\n  line" + "\n\n" +
+		"1. one\n2. two" + "\n\n" +
+		"````go\nfmt.Println(\"```\")\n````" + "\n\n" +
+		"###### Too deep"
+
+	if got := b.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Account is the account of the authenticated user
+	Account struct {
+		Username      string                `json:"username"`
+		Role          string                `json:"role,omitempty"` // admin, user or anonymous
+		SyncTopic     string                `json:"sync_topic,omitempty"`
+		Language      string                `json:"language,omitempty"`
+		Notification  *NotificationPrefs    `json:"notification,omitempty"`
+		Subscriptions []AccountSubscription `json:"subscriptions,omitempty"`
+		Reservations  []Reservation         `json:"reservations,omitempty"`
+		Tokens        []AccessToken         `json:"tokens,omitempty"`
+		PhoneNumbers  []string              `json:"phone_numbers,omitempty"`
+		Tier          *AccountTier          `json:"tier,omitempty"`
+		Limits        *AccountLimits        `json:"limits,omitempty"`
+		Stats         *AccountStats         `json:"stats,omitempty"`
+	}
+
+	// AccountTier is the tier the account is assigned to
+	AccountTier struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+
+	// AccountLimits are the usage limits of the account. Durations are in
+	// seconds and sizes in bytes
+	AccountLimits struct {
+		Basis                    string `json:"basis,omitempty"` // role, tier or ip
+		Messages                 int64  `json:"messages"`
+		MessagesExpiryDuration   int64  `json:"messages_expiry_duration"`
+		Emails                   int64  `json:"emails"`
+		Calls                    int64  `json:"calls"`
+		Reservations             int64  `json:"reservations"`
+		AttachmentTotalSize      int64  `json:"attachment_total_size"`
+		AttachmentFileSize       int64  `json:"attachment_file_size"`
+		AttachmentExpiryDuration int64  `json:"attachment_expiry_duration"`
+		AttachmentBandwidth      int64  `json:"attachment_bandwidth"`
+	}
+
+	// AccountStats is the current usage of the account
+	AccountStats struct {
+		Messages                     int64 `json:"messages"`
+		MessagesRemaining            int64 `json:"messages_remaining"`
+		Emails                       int64 `json:"emails"`
+		EmailsRemaining              int64 `json:"emails_remaining"`
+		Calls                        int64 `json:"calls"`
+		CallsRemaining               int64 `json:"calls_remaining"`
+		Reservations                 int64 `json:"reservations"`
+		ReservationsRemaining        int64 `json:"reservations_remaining"`
+		AttachmentTotalSize          int64 `json:"attachment_total_size"`
+		AttachmentTotalSizeRemaining int64 `json:"attachment_total_size_remaining"`
+	}
+
+	// NotificationPrefs are the notification settings of the web app
+	NotificationPrefs struct {
+		Sound       string   `json:"sound,omitempty"`
+		MinPriority Priority `json:"min_priority,omitempty"`
+		DeleteAfter int64    `json:"delete_after,omitempty"` // In seconds
+	}
+
+	// AccountSubscription is a topic subscription synced with the account
+	AccountSubscription struct {
+		BaseURL     string `json:"base_url"`
+		Topic       string `json:"topic"`
+		DisplayName string `json:"display_name,omitempty"`
+	}
+
+	// AccountSettings are the account settings to update. Empty fields are
+	// left unchanged
+	AccountSettings struct {
+		Language     string             `json:"language,omitempty"`
+		Notification *NotificationPrefs `json:"notification,omitempty"`
+	}
+
+	// AccessToken is an access token of the account
+	AccessToken struct {
+		Token      string `json:"token"`
+		Label      string `json:"label,omitempty"`
+		LastAccess int64  `json:"last_access,omitempty"` // Unix time
+		LastOrigin string `json:"last_origin,omitempty"`
+		Expires    int64  `json:"expires,omitempty"` // Unix time, zero if the token never expires
+	}
+
+	accountRequest struct {
+		Username    string `json:"username,omitempty"`
+		Password    string `json:"password,omitempty"`
+		NewPassword string `json:"new_password,omitempty"`
+	}
+)
+
+// SignUp creates a new account. The server must have sign-up enabled
+func (c *Client) SignUp(ctx context.Context, username, password string) error {
+	if err := c.validator.Var(username, "required"); err != nil {
+		return err
+	}
+
+	if err := c.validator.Var(password, "required"); err != nil {
+		return err
+	}
+
+	req := &accountRequest{Username: username, Password: password}
+	return c.doJSON(ctx, http.MethodPost, c.endpoint("v1", "account"), req, nil, nil)
+}
+
+// Login creates an access token for the given username and password,
+// regardless of the credentials the client is configured with. The token
+// can be used with WithToken
+func (c *Client) Login(ctx context.Context, username, password string) (*AccessToken, error) {
+	if err := c.validator.Var(username, "required"); err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{"Authorization": {BasicAuth(username, password).Header()}}
+
+	var token AccessToken
+	if err := c.doJSON(ctx, http.MethodPost, c.endpoint("v1", "account", "token"), nil, &token, headers); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Account returns the account of the authenticated user, including its
+// tier, limits and usage
+func (c *Client) Account(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := c.doJSON(ctx, http.MethodGet, c.endpoint("v1", "account"), nil, &account, nil); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// ChangePassword changes the password of the authenticated user
+func (c *Client) ChangePassword(ctx context.Context, current, password string) error {
+	if err := c.validator.Var(password, "required"); err != nil {
+		return err
+	}
+
+	req := &accountRequest{Password: current, NewPassword: password}
+	return c.doJSON(ctx, http.MethodPost, c.endpoint("v1", "account", "password"), req, nil, nil)
+}
+
+// UpdateAccountSettings updates the settings of the authenticated user
+func (c *Client) UpdateAccountSettings(ctx context.Context, settings *AccountSettings) error {
+	if err := c.validator.Var(settings, "required"); err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPatch, c.endpoint("v1", "account", "settings"), settings, nil, nil)
+}
+
+// DeleteAccount deletes the account of the authenticated user. The current
+// password is required to confirm the deletion
+func (c *Client) DeleteAccount(ctx context.Context, password string) error {
+	if err := c.validator.Var(password, "required"); err != nil {
+		return err
+	}
+
+	req := &accountRequest{Password: password}
+	return c.doJSON(ctx, http.MethodDelete, c.endpoint("v1", "account"), req, nil, nil)
+}
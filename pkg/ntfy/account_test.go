@@ -0,0 +1,176 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recordedRequest is a request received by a test server
+type recordedRequest struct {
+	method string
+	path   string
+	body   string
+	auth   string
+	token  string // X-Token header
+	delete string // X-Delete-Messages header
+}
+
+// newRecordingServer returns a server answering every request with the
+// given status and body, and recording the last request it received
+func newRecordingServer(t *testing.T, status int, body string) (*httptest.Server, *recordedRequest) {
+	t.Helper()
+
+	rec := &recordedRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*rec = recordedRequest{method: r.Method, path: r.URL.Path, body: string(b), auth: r.Header.Get("Authorization"), token: r.Header.Get("X-Token"), delete: r.Header.Get("X-Delete-Messages")}
+		w.WriteHeader(status)
+		fmt.Fprintln(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, rec
+}
+
+func TestAccountRequests(t *testing.T) {
+	testCases := []struct {
+		name     string
+		call     func(c *Client) error
+		expected recordedRequest
+	}{
+		{
+			name: "Sign Up",
+			call: func(c *Client) error {
+				return c.SignUp(context.Background(), "phil", "secret")
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account",
+				body:   `{"username":"phil","password":"secret"}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Change Password",
+			call: func(c *Client) error {
+				return c.ChangePassword(context.Background(), "old", "new")
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/password",
+				body:   `{"password":"old","new_password":"new"}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Update Settings",
+			call: func(c *Client) error {
+				return c.UpdateAccountSettings(context.Background(), &AccountSettings{
+					Language:     "de",
+					Notification: &NotificationPrefs{MinPriority: High},
+				})
+			},
+			expected: recordedRequest{
+				method: http.MethodPatch,
+				path:   "/v1/account/settings",
+				body:   `{"language":"de","notification":{"min_priority":4}}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Delete Account",
+			call: func(c *Client) error {
+				return c.DeleteAccount(context.Background(), "secret")
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/account",
+				body:   `{"password":"secret"}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Login",
+			call: func(c *Client) error {
+				_, err := c.Login(context.Background(), "phil", "secret")
+				return err
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/token",
+				auth:   "Basic cGhpbDpzZWNyZXQ=",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, rec := newRecordingServer(t, http.StatusOK, `{"success":true}`)
+
+			c, err := New(WithHost(srv.URL), WithToken("tk_client"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := tc.call(c); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if *rec != tc.expected {
+				t.Errorf("Expected request %+v, got %+v", tc.expected, *rec)
+			}
+		})
+	}
+}
+
+func TestAccount(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusOK, `{
+		"username": "phil",
+		"role": "user",
+		"language": "en",
+		"tier": {"code": "pro", "name": "Pro"},
+		"limits": {"basis": "tier", "messages": 10000, "reservations": 5, "attachment_file_size": 15728640},
+		"stats": {"messages": 12, "messages_remaining": 9988}
+	}`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	actual, err := c.Account(context.Background())
+	if err != nil {
+		t.Fatalf("Account() error = %v", err)
+	}
+
+	expected := &Account{
+		Username: "phil",
+		Role:     "user",
+		Language: "en",
+		Tier:     &AccountTier{Code: "pro", Name: "Pro"},
+		Limits:   &AccountLimits{Basis: "tier", Messages: 10000, Reservations: 5, AttachmentFileSize: 15728640},
+		Stats:    &AccountStats{Messages: 12, MessagesRemaining: 9988},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestLoginUnauthorized(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusUnauthorized, `{"code":40101,"http":401,"error":"unauthorized"}`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.Login(context.Background(), "phil", "wrong"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected error %v, got %v", ErrUnauthorized, err)
+	}
+}
@@ -1,5 +1,7 @@
 package ntfy
 
+import "net/url"
+
 const (
 	UnspecifiedAction ActionButtonType = iota
 	View
@@ -14,3 +16,12 @@ type (
 		actionType() ActionButtonType
 	}
 )
+
+// parseOptionalURL parses the given URL, returning nil if it is empty
+func parseOptionalURL(s string) (*url.URL, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	return url.Parse(s)
+}
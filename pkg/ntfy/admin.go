@@ -0,0 +1,130 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+)
+
+// Everyone is the user name that refers to all users, including anonymous
+// ones, in access control entries
+const Everyone = "everyone"
+
+type (
+	// Admin manages the users and access control list of a self-hosted
+	// server. The client must be authenticated as an admin user
+	Admin struct {
+		client *Client
+	}
+
+	// User is a user as listed by the admin API
+	User struct {
+		Username string      `json:"username"`
+		Role     string      `json:"role"` // admin or user
+		Tier     string      `json:"tier,omitempty"`
+		Grants   []UserGrant `json:"grants,omitempty"`
+	}
+
+	// UserGrant is the access a user has to the topics matching a pattern
+	UserGrant struct {
+		Topic      string     `json:"topic"` // Topic name or pattern, e.g. alerts_*
+		Permission Permission `json:"permission"`
+	}
+
+	// UserOpts are the properties of a user to create or update. Empty
+	// fields are left unchanged when updating
+	UserOpts struct {
+		Username string `json:"username" validate:"required"`
+		Password string `json:"password,omitempty"`
+		Hash     string `json:"hash,omitempty"` // Bcrypt hash, used instead of Password
+		Tier     string `json:"tier,omitempty"` // Tier code
+	}
+
+	accessRequest struct {
+		Username   string     `json:"username" validate:"required"`
+		Topic      string     `json:"topic" validate:"required"`
+		Permission Permission `json:"permission,omitempty" validate:"omitempty,oneof=read-write read-only write-only deny-all"`
+	}
+)
+
+// Admin returns the admin API of the server
+func (c *Client) Admin() *Admin {
+	return &Admin{client: c}
+}
+
+// Users returns all users and their grants
+func (a *Admin) Users(ctx context.Context) ([]User, error) {
+	var users []User
+	if err := a.client.doJSON(ctx, http.MethodGet, a.client.endpoint("v1", "users"), nil, &users, nil); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CreateUser creates a user with the role user. Either a password or a
+// password hash is required
+func (a *Admin) CreateUser(ctx context.Context, opts *UserOpts) error {
+	if err := a.client.validator.Struct(opts); err != nil {
+		return err
+	}
+
+	if err := a.client.validator.Var(opts.Password+opts.Hash, "required"); err != nil {
+		return err
+	}
+
+	return a.client.doJSON(ctx, http.MethodPut, a.client.endpoint("v1", "users"), opts, nil, nil)
+}
+
+// UpdateUser changes the password or tier of a user
+func (a *Admin) UpdateUser(ctx context.Context, opts *UserOpts) error {
+	if err := a.client.validator.Struct(opts); err != nil {
+		return err
+	}
+
+	return a.client.doJSON(ctx, http.MethodPost, a.client.endpoint("v1", "users"), opts, nil, nil)
+}
+
+// AssignTier changes the tier of a user
+func (a *Admin) AssignTier(ctx context.Context, username, tier string) error {
+	if err := a.client.validator.Var(tier, "required"); err != nil {
+		return err
+	}
+
+	return a.UpdateUser(ctx, &UserOpts{Username: username, Tier: tier})
+}
+
+// DeleteUser deletes a user along with its grants and tokens
+func (a *Admin) DeleteUser(ctx context.Context, username string) error {
+	if err := a.client.validator.Var(username, "required"); err != nil {
+		return err
+	}
+
+	req := &UserOpts{Username: username}
+	return a.client.doJSON(ctx, http.MethodDelete, a.client.endpoint("v1", "users"), req, nil, nil)
+}
+
+// GrantAccess grants a user the given access to the topics matching the
+// pattern, which may contain * wildcards. Use Everyone as the user name to
+// grant access to all users
+func (a *Admin) GrantAccess(ctx context.Context, username, topic string, permission Permission) error {
+	if err := a.client.validator.Var(permission, "required"); err != nil {
+		return err
+	}
+
+	req := &accessRequest{Username: username, Topic: topic, Permission: permission}
+	if err := a.client.validator.Struct(req); err != nil {
+		return err
+	}
+
+	return a.client.doJSON(ctx, http.MethodPut, a.client.endpoint("v1", "users", "access"), req, nil, nil)
+}
+
+// RevokeAccess removes the grant of a user for the given topic pattern
+func (a *Admin) RevokeAccess(ctx context.Context, username, topic string) error {
+	req := &accessRequest{Username: username, Topic: topic}
+	if err := a.client.validator.Struct(req); err != nil {
+		return err
+	}
+
+	return a.client.doJSON(ctx, http.MethodDelete, a.client.endpoint("v1", "users", "access"), req, nil, nil)
+}
@@ -0,0 +1,148 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAdminRequests(t *testing.T) {
+	testCases := []struct {
+		name        string
+		call        func(a *Admin) error
+		expected    recordedRequest
+		expectedErr bool
+	}{
+		{
+			name: "Create User",
+			call: func(a *Admin) error {
+				return a.CreateUser(context.Background(), &UserOpts{Username: "svc-ci", Password: "secret", Tier: "pro"})
+			},
+			expected: recordedRequest{
+				method: http.MethodPut,
+				path:   "/v1/users",
+				body:   `{"username":"svc-ci","password":"secret","tier":"pro"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+		{
+			name: "Create User Without Password",
+			call: func(a *Admin) error {
+				return a.CreateUser(context.Background(), &UserOpts{Username: "svc-ci"})
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Update User",
+			call: func(a *Admin) error {
+				return a.UpdateUser(context.Background(), &UserOpts{Username: "svc-ci", Hash: "$2a$10$abc"})
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/users",
+				body:   `{"username":"svc-ci","hash":"$2a$10$abc"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+		{
+			name: "Assign Tier",
+			call: func(a *Admin) error {
+				return a.AssignTier(context.Background(), "svc-ci", "business")
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/users",
+				body:   `{"username":"svc-ci","tier":"business"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+		{
+			name: "Delete User",
+			call: func(a *Admin) error {
+				return a.DeleteUser(context.Background(), "svc-ci")
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/users",
+				body:   `{"username":"svc-ci"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+		{
+			name: "Grant Access",
+			call: func(a *Admin) error {
+				return a.GrantAccess(context.Background(), Everyone, "alerts_*", ReadOnly)
+			},
+			expected: recordedRequest{
+				method: http.MethodPut,
+				path:   "/v1/users/access",
+				body:   `{"username":"everyone","topic":"alerts_*","permission":"read-only"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+		{
+			name: "Grant Invalid Permission",
+			call: func(a *Admin) error {
+				return a.GrantAccess(context.Background(), "svc-ci", "alerts", "all")
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Revoke Access",
+			call: func(a *Admin) error {
+				return a.RevokeAccess(context.Background(), "svc-ci", "alerts_*")
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/users/access",
+				body:   `{"username":"svc-ci","topic":"alerts_*"}`,
+				auth:   "Basic YWRtaW46YWRtaW4=",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, rec := newRecordingServer(t, http.StatusOK, `{"success":true}`)
+
+			c, err := New(WithHost(srv.URL), WithBasicAuth("admin", "admin"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := tc.call(c.Admin()); (err != nil) != tc.expectedErr {
+				t.Fatalf("Unexpected error = %v, expectedErr %v", err, tc.expectedErr)
+			}
+
+			if *rec != tc.expected {
+				t.Errorf("Expected request %+v, got %+v", tc.expected, *rec)
+			}
+		})
+	}
+}
+
+func TestAdminUsers(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusOK, `[
+		{"username":"admin","role":"admin"},
+		{"username":"svc-ci","role":"user","tier":"pro","grants":[{"topic":"ci_*","permission":"read-write"}]}
+	]`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	actual, err := c.Admin().Users(context.Background())
+	if err != nil {
+		t.Fatalf("Users() error = %v", err)
+	}
+
+	expected := []User{
+		{Username: "admin", Role: "admin"},
+		{Username: "svc-ci", Role: "user", Tier: "pro", Grants: []UserGrant{{Topic: "ci_*", Permission: ReadWrite}}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
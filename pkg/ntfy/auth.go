@@ -0,0 +1,47 @@
+package ntfy
+
+import (
+	"encoding/base64"
+)
+
+// Credentials authenticate requests to the ntfy server. They are redacted
+// when formatted, so that values holding them can be logged safely
+type Credentials struct {
+	username string
+	password string
+	token    string
+	bearer   bool // Whether the credentials are an access token
+}
+
+// BasicAuth returns credentials for the given username and password
+func BasicAuth(username, password string) *Credentials {
+	return &Credentials{username: username, password: password}
+}
+
+// Token returns credentials for the given access token, e.g. tk_...
+func Token(token string) *Credentials {
+	return &Credentials{token: token, bearer: true}
+}
+
+// Header returns the value of the Authorization header
+func (c *Credentials) Header() string {
+	if c.bearer {
+		return "Bearer " + c.token
+	}
+
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+}
+
+// QueryParam returns the value of the auth query parameter, for contexts
+// in which only a URL can be passed
+func (c *Credentials) QueryParam() string {
+	return authQueryParam(c.Header())
+}
+
+func (c *Credentials) String() string {
+	return "[redacted]"
+}
+
+func (c *Credentials) GoString() string {
+	return "&ntfy.Credentials{[redacted]}"
+}
@@ -0,0 +1,93 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		creds      *Credentials
+		wantHeader string
+		wantParam  string
+	}{
+		{
+			name:       "Basic auth",
+			creds:      BasicAuth("testuser", "fakepassword"),
+			wantHeader: "Basic dGVzdHVzZXI6ZmFrZXBhc3N3b3Jk",
+			wantParam:  "QmFzaWMgZEdWemRIVnpaWEk2Wm1GclpYQmhjM04zYjNKaw",
+		},
+		{
+			name:       "Token",
+			creds:      Token("tk_AgQdq7mVBoFD37zQVN29RhuMzNIz2"),
+			wantHeader: "Bearer tk_AgQdq7mVBoFD37zQVN29RhuMzNIz2",
+			wantParam:  "QmVhcmVyIHRrX0FnUWRxN21WQm9GRDM3elFWTjI5Umh1TXpOSXoy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.creds.Header(); got != tt.wantHeader {
+				t.Errorf("Header() = %q, want %q", got, tt.wantHeader)
+			}
+			if got := tt.creds.QueryParam(); got != tt.wantParam {
+				t.Errorf("QueryParam() = %q, want %q", got, tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestCredentialsRedacted(t *testing.T) {
+	o := &Options{}
+	WithBasicAuth("testuser", "fakepassword")(o)
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		if got := fmt.Sprintf(format, o.Auth); strings.Contains(got, "fakepassword") || strings.Contains(got, "testuser") {
+			t.Errorf("credentials leaked with format %s: %s", format, got)
+		}
+	}
+}
+
+func TestClientAuth(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprintln(w, `{"id":"m1","event":"message","topic":"t1"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL), WithToken("tk_test"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.Publish(context.Background(), &PublishOpts{Message: &Message{Topic: "t1"}}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if gotAuth != "Bearer tk_test" {
+		t.Errorf("unexpected Authorization header on publish: got = %q, want = %q", gotAuth, "Bearer tk_test")
+	}
+
+	if _, _, err := c.Poll(context.Background(), &PollOpts{Topics: []string{"t1"}}); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if gotAuth != "Bearer tk_test" {
+		t.Errorf("unexpected Authorization header on poll: got = %q, want = %q", gotAuth, "Bearer tk_test")
+	}
+
+	if got, want := c.AuthQueryParam(), Token("tk_test").QueryParam(); got != want {
+		t.Errorf("AuthQueryParam() = %q, want %q", got, want)
+	}
+}
+
+func TestEmptyToken(t *testing.T) {
+	if _, err := New(WithToken("")); !errors.Is(err, ErrMissingToken) {
+		t.Errorf("New(WithToken(\"\")) error = %v, want %v", err, ErrMissingToken)
+	}
+}
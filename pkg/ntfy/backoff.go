@@ -0,0 +1,62 @@
+package ntfy
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff computes exponentially increasing delays with random jitter.
+// Unset fields take their value from DefaultBackoff, except Jitter
+type Backoff struct {
+	Initial    time.Duration // Delay before the first retry
+	Max        time.Duration // Upper bound of the delay
+	Multiplier float64       // Factor applied to the delay after each attempt, at least 1
+	Jitter     float64       // Fraction of the delay that is randomized, between 0 and 1
+}
+
+// DefaultBackoff is used when no backoff is configured
+var DefaultBackoff = Backoff{
+	Initial:    time.Second,
+	Max:        time.Minute,
+	Multiplier: 2,
+	Jitter:     0.5,
+}
+
+// Delay returns the delay to wait before the given retry attempt, starting at 0
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b.Initial <= 0 {
+		b.Initial = DefaultBackoff.Initial
+	}
+	if b.Max <= 0 {
+		b.Max = max(DefaultBackoff.Max, b.Initial)
+	}
+	if b.Multiplier < 1 {
+		b.Multiplier = DefaultBackoff.Multiplier
+	}
+
+	d := float64(b.Initial) * math.Pow(b.Multiplier, float64(attempt))
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+
+	if b.Jitter > 0 {
+		d -= d * math.Min(b.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// sleep waits for the given duration or until the context is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,57 @@
+package ntfy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 100 * time.Millisecond},
+		{attempt: 1, want: 200 * time.Millisecond},
+		{attempt: 3, want: 800 * time.Millisecond},
+		{attempt: 4, want: time.Second},
+		{attempt: 50, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := b.Delay(tt.attempt); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := b.Delay(1); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("Delay(1) with jitter = %v, want between 100ms and 200ms", got)
+		}
+	}
+}
+
+func TestBackoffDelayDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{name: "Zero value", backoff: Backoff{}, attempt: 1, want: 2 * time.Second},
+		{name: "Initial only", backoff: Backoff{Initial: 100 * time.Millisecond}, attempt: 2, want: 400 * time.Millisecond},
+		{name: "Multiplier below 1", backoff: Backoff{Initial: time.Second, Multiplier: 0.5}, attempt: 1, want: 2 * time.Second},
+		{name: "Constant", backoff: Backoff{Initial: time.Second, Multiplier: 1}, attempt: 10, want: time.Second},
+		{name: "Default max", backoff: Backoff{Initial: time.Second}, attempt: 20, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.Delay(tt.attempt); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of concurrent requests used by
+// PublishBatch when no concurrency is configured
+const DefaultBatchConcurrency = 8
+
+type (
+	// BatchOpts configures how PublishBatch publishes messages
+	BatchOpts struct {
+		Concurrency int  `validate:"gte=0"` // Maximum number of concurrent requests
+		FailFast    bool // Stop publishing remaining messages after the first failure
+	}
+
+	// BatchResult is the outcome of publishing a single message of a batch
+	BatchResult struct {
+		Result *PublishResult
+		Err    error
+	}
+)
+
+// ErrBatchAborted is set as the error of messages that were not published
+// because an earlier message of a fail-fast batch failed
+var ErrBatchAborted = errors.New("batch aborted after an earlier failure")
+
+// PublishBatch publishes the given messages concurrently and returns a
+// result for each of them, in input order. The returned error is nil if all
+// messages were published. Otherwise it is the first failure in fail-fast
+// mode, or all failures joined in best-effort mode
+func (c *Client) PublishBatch(ctx context.Context, batch []*PublishOpts, opts BatchOpts) ([]BatchResult, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	concurrency = min(concurrency, len(batch))
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		results  = make([]BatchResult, len(batch))
+		jobs     = make(chan int)
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				if ctx.Err() != nil {
+					results[idx].Err = context.Cause(ctx)
+					continue
+				}
+
+				res, err := c.Publish(ctx, batch[idx])
+				results[idx] = BatchResult{Result: res, Err: err}
+
+				if err != nil && opts.FailFast {
+					once.Do(func() {
+						firstErr = err
+						cancel(ErrBatchAborted)
+					})
+				}
+			}
+		}()
+	}
+
+	for i := range batch {
+		if ctx.Err() != nil {
+			results[i].Err = context.Cause(ctx)
+			continue
+		}
+
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i].Err = context.Cause(ctx)
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if opts.FailFast {
+		if firstErr != nil {
+			return results, firstErr
+		}
+
+		// No publish failed, but the parent context was cancelled before
+		// every message was sent
+		for _, r := range results {
+			if r.Err != nil {
+				return results, context.Cause(ctx)
+			}
+		}
+
+		return results, nil
+	}
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,155 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublishBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var m message
+		json.NewDecoder(r.Body).Decode(&m)
+		if m.Topic == "fail" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(PublishResult{ID: m.Message, Topic: m.Topic})
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	topics := []string{"t1", "t2", "fail", "t4", "t5", "t6"}
+	batch := make([]*PublishOpts, len(topics))
+	for i, topic := range topics {
+		batch[i] = &PublishOpts{Message: &Message{Topic: topic, Message: topic + "-id"}}
+	}
+
+	results, err := c.PublishBatch(context.Background(), batch, BatchOpts{Concurrency: 2})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("unexpected error: got = %v, want = %v", err, ErrForbidden)
+	}
+
+	if len(results) != len(topics) {
+		t.Fatalf("unexpected number of results: got = %d, want = %d", len(results), len(topics))
+	}
+
+	for i, topic := range topics {
+		r := results[i]
+		if topic == "fail" {
+			if !errors.Is(r.Err, ErrForbidden) {
+				t.Errorf("result %d: unexpected error: %v", i, r.Err)
+			}
+			continue
+		}
+
+		if r.Err != nil || r.Result == nil || r.Result.ID != topic+"-id" {
+			t.Errorf("result %d: unexpected result: %+v, error = %v", i, r.Result, r.Err)
+		}
+	}
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("concurrency limit exceeded: got = %d, want <= 2", got)
+	}
+}
+
+func TestPublishBatchFailFast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m message
+		json.NewDecoder(r.Body).Decode(&m)
+		if m.Topic == "fail" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(PublishResult{ID: "m1", Topic: m.Topic})
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	batch := []*PublishOpts{
+		{Message: &Message{Topic: "t1"}},
+		{Message: &Message{Topic: "fail"}},
+		{Message: &Message{Topic: "t3"}},
+		{Message: &Message{Topic: "t4"}},
+	}
+
+	results, err := c.PublishBatch(context.Background(), batch, BatchOpts{Concurrency: 1, FailFast: true})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("unexpected error: got = %v, want = %v", err, ErrRateLimited)
+	}
+
+	if results[0].Err != nil || results[0].Result == nil {
+		t.Errorf("expected first message to be published, got error = %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrRateLimited) {
+		t.Errorf("unexpected error for failed message: %v", results[1].Err)
+	}
+	for _, r := range results[2:] {
+		if !errors.Is(r.Err, ErrBatchAborted) {
+			t.Errorf("unexpected error for remaining message: got = %v, want = %v", r.Err, ErrBatchAborted)
+		}
+	}
+}
+
+func TestPublishBatchCancelled(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(PublishResult{ID: "m1"})
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	batch := []*PublishOpts{
+		{Message: &Message{Topic: "t1"}},
+		{Message: &Message{Topic: "t2"}},
+	}
+
+	for _, failFast := range []bool{true, false} {
+		results, err := c.PublishBatch(ctx, batch, BatchOpts{FailFast: failFast})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error with FailFast = %v: got = %v, want = %v", failFast, err, context.Canceled)
+		}
+
+		for i, r := range results {
+			if !errors.Is(r.Err, context.Canceled) {
+				t.Errorf("unexpected error for message %d: got = %v, want = %v", i, r.Err, context.Canceled)
+			}
+		}
+	}
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("unexpected number of requests: got = %d, want = 0", n)
+	}
+}
@@ -0,0 +1,83 @@
+package ntfy
+
+import (
+	"encoding/json"
+)
+
+// DefaultBroadcastIntent is the Android intent sent by a BroadcastAction without an explicit intent
+const DefaultBroadcastIntent = "io.heckel.ntfy.USER_ACTION"
+
+type (
+	// BroadcastAction allows sending an Android broadcast intent when the
+	// action button is tapped, e.g. to trigger Tasker or MacroDroid flows
+	BroadcastAction struct {
+		Label  string            // Label of the action button in the notification
+		Intent string            // Android intent name, default is io.heckel.ntfy.USER_ACTION
+		Extras map[string]string // Android intent extras
+		Clear  bool              // Clear notification after action button is tapped
+	}
+
+	broadcastAction struct {
+		Action string            `json:"action"`
+		Label  string            `json:"label"`
+		Intent string            `json:"intent,omitempty"`
+		Extras map[string]string `json:"extras,omitempty"`
+		Clear  bool              `json:"clear,omitempty"`
+	}
+)
+
+func (b *BroadcastAction) actionType() ActionButtonType {
+	return Broadcast
+}
+
+func (b *BroadcastAction) intent() string {
+	if b.Intent == "" {
+		return DefaultBroadcastIntent
+	}
+
+	return b.Intent
+}
+
+func (b *BroadcastAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&broadcastAction{
+		Action: "broadcast",
+		Label:  b.Label,
+		Intent: b.intent(),
+		Extras: b.Extras,
+		Clear:  b.Clear,
+	})
+}
+
+func (b *BroadcastAction) UnmarshalJSON(data []byte) error {
+	var aux broadcastAction
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*b = BroadcastAction{
+		Label:  aux.Label,
+		Intent: aux.Intent,
+		Extras: aux.Extras,
+		Clear:  aux.Clear,
+	}
+
+	return nil
+}
+
+// MarshalText encodes the action in ntfy's short form, e.g.
+// broadcast, Take picture, extras.cmd=pic, clear=true
+func (b *BroadcastAction) MarshalText() ([]byte, error) {
+	var keyed []actionField
+	if b.Intent != "" {
+		keyed = append(keyed, actionField{key: "intent", value: b.Intent})
+	}
+	keyed = append(keyed, mapFields("extras.", b.Extras)...)
+
+	return formatAction("broadcast", b.Label, nil, keyed, b.Clear)
+}
+
+// String returns the action in ntfy's short form
+func (b *BroadcastAction) String() string {
+	text, _ := b.MarshalText()
+	return string(text)
+}
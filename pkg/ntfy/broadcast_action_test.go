@@ -0,0 +1,103 @@
+package ntfy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBroadcastMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  BroadcastAction
+		want    broadcastAction
+		wantErr bool
+	}{
+		{
+			name: "Simple case",
+			action: BroadcastAction{
+				Label:  "Take picture",
+				Intent: "com.example.PICTURE",
+				Extras: map[string]string{"cmd": "pic", "camera": "front"},
+				Clear:  true,
+			},
+			want: broadcastAction{
+				Action: "broadcast",
+				Label:  "Take picture",
+				Intent: "com.example.PICTURE",
+				Extras: map[string]string{"cmd": "pic", "camera": "front"},
+				Clear:  true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Default intent",
+			action: BroadcastAction{
+				Label: "Default",
+			},
+			want: broadcastAction{
+				Action: "broadcast",
+				Label:  "Default",
+				Intent: DefaultBroadcastIntent,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.action.MarshalJSON()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BroadcastAction.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var gotStruct broadcastAction
+			if err := json.Unmarshal(got, &gotStruct); err != nil {
+				t.Errorf("json.Unmarshal() error = %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(gotStruct, tt.want) {
+				t.Errorf("BroadcastAction.MarshalJSON() = %v, want %v", gotStruct, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcastString(t *testing.T) {
+	tests := []struct {
+		name   string
+		action BroadcastAction
+		want   string
+	}{
+		{
+			name:   "Label only",
+			action: BroadcastAction{Label: "Ping"},
+			want:   "broadcast, Ping",
+		},
+		{
+			name: "All fields",
+			action: BroadcastAction{
+				Label:  "Take picture",
+				Intent: "com.example.PICTURE",
+				Extras: map[string]string{"cmd": "pic", "camera": "front, rear"},
+				Clear:  true,
+			},
+			want: `broadcast, Take picture, intent=com.example.PICTURE, extras.camera="front, rear", extras.cmd=pic, clear=true`,
+		},
+		{
+			name:   "Label with double quotes",
+			action: BroadcastAction{Label: `Say "hi"`},
+			want:   `broadcast, 'Say "hi"'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.action.String(); got != tt.want {
+				t.Errorf("BroadcastAction.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,45 +5,63 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
 type (
 	Client struct {
-		httpClient *http.Client
-		validator  *validator.Validate
-		host       *url.URL
-		headers    http.Header
+		httpClient  *http.Client
+		validator   *validator.Validate
+		host        *url.URL
+		headers     http.Header
+		auth        *Credentials
+		retryPolicy *RetryPolicy
 	}
 
 	PublishOpts struct {
-		Message *Message    `validate:"required"`
-		Headers http.Header `validate:"-"`
+		Message  *Message        `validate:"required"`
+		Headers  http.Header     `validate:"-"`
+		Encoding PublishEncoding // How the message is encoded in the request
 	}
 
 	PublishResult struct {
-		ID      string `json:"id"`
-		Time    int    `json:"time"`
-		Expires int    `json:"expires"`
-		Event   string `json:"event"`
-		Topic   string `json:"topic"`
-		Message string `json:"message"`
+		ID         string      `json:"id"`
+		Time       int         `json:"time"`
+		Expires    int         `json:"expires"`
+		Event      string      `json:"event"`
+		Topic      string      `json:"topic"`
+		Message    string      `json:"message"`
+		Attachment *Attachment `json:"attachment,omitempty"`
 	}
 
 	Options struct {
-		HTTPClient *http.Client
-		Validator  *validator.Validate
-		Headers    http.Header
-		Host       string
+		HTTPClient  *http.Client
+		Validator   *validator.Validate
+		Headers     http.Header
+		Host        string
+		Auth        *Credentials
+		RetryPolicy *RetryPolicy
 	}
 
 	Option func(*Options)
 )
 
+// PublishEncoding selects how a message is encoded when publishing
+type PublishEncoding byte
+
+const (
+	// JSONEncoding posts the JSON encoded message to the host root
+	JSONEncoding PublishEncoding = iota
+	// HeaderEncoding posts the message body to the topic URL and encodes
+	// all other fields as X-* headers
+	HeaderEncoding
+)
+
 var (
 	ErrMissingHost       = errors.New("missing host")
 	ErrMissingHTTPClient = errors.New("missing http client")
@@ -74,6 +92,28 @@ func WithHost(host string) Option {
 	}
 }
 
+// WithBasicAuth authenticates every request with the given username and password
+func WithBasicAuth(username, password string) Option {
+	return func(o *Options) {
+		o.Auth = BasicAuth(username, password)
+	}
+}
+
+// WithToken authenticates every request with the given access token
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.Auth = Token(token)
+	}
+}
+
+// WithRetryPolicy retries requests that failed with a transient error. An
+// unset Backoff behaves like DefaultBackoff
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = &p
+	}
+}
+
 // New creates a ntfy client with the given options
 func New(opts ...Option) (*Client, error) {
 	options := &Options{
@@ -98,16 +138,22 @@ func New(opts ...Option) (*Client, error) {
 		return nil, ErrMissingHost
 	}
 
+	if options.Auth != nil && options.Auth.bearer && options.Auth.token == "" {
+		return nil, ErrMissingToken
+	}
+
 	host, err := url.Parse(options.Host)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		httpClient: options.HTTPClient,
-		validator:  options.Validator,
-		headers:    options.Headers,
-		host:       host,
+		httpClient:  options.HTTPClient,
+		validator:   options.Validator,
+		headers:     options.Headers,
+		host:        host,
+		auth:        options.Auth,
+		retryPolicy: options.RetryPolicy,
 	}, nil
 }
 
@@ -117,12 +163,75 @@ func (c *Client) Publish(ctx context.Context, opts *PublishOpts) (*PublishResult
 		return nil, err
 	}
 
+	req, err := c.newPublishRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.publish(req)
+}
+
+// publish sends a publish request and decodes the published message
+func (c *Client) publish(req *http.Request) (*PublishResult, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pubResp PublishResult
+	if err = json.NewDecoder(resp.Body).Decode(&pubResp); err != nil {
+		return nil, err
+	}
+
+	return &pubResp, nil
+}
+
+// newPublishRequest creates the request publishing the message with the requested encoding
+func (c *Client) newPublishRequest(ctx context.Context, opts *PublishOpts) (*http.Request, error) {
+	if opts.Encoding == HeaderEncoding {
+		headers, err := opts.Message.headers()
+		if err != nil {
+			return nil, err
+		}
+		headers.Del("X-Message")
+		headers.Set("Content-Type", "text/plain; charset=utf-8")
+
+		for key, values := range opts.Headers {
+			headers[http.CanonicalHeaderKey(key)] = values
+		}
+
+		u := c.endpoint(opts.Message.Topic)
+		return c.newRequest(ctx, http.MethodPost, u, strings.NewReader(opts.Message.Message), headers)
+	}
+
 	buf, err := json.Marshal(opts.Message)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.host.String(), bytes.NewReader(buf))
+	return c.newRequest(ctx, http.MethodPost, c.host, bytes.NewReader(buf), opts.Headers)
+}
+
+// AuthQueryParam returns the value of the auth query parameter for the
+// configured credentials, or an empty string if none are configured
+func (c *Client) AuthQueryParam() string {
+	if c.auth == nil {
+		return ""
+	}
+
+	return c.auth.QueryParam()
+}
+
+// endpoint returns the URL of the given path elements relative to the host
+func (c *Client) endpoint(elem ...string) *url.URL {
+	return c.host.JoinPath(elem...)
+}
+
+// newRequest creates a request carrying the client headers and credentials,
+// overridden by any per-request headers
+func (c *Client) newRequest(ctx context.Context, method string, u *url.URL, body io.Reader, headers http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -133,29 +242,79 @@ func (c *Client) Publish(ctx context.Context, opts *PublishOpts) (*PublishResult
 		}
 	}
 
-	if opts.Headers != nil {
-		for key, values := range opts.Headers {
-			req.Header.Del(key)
-			for _, value := range values {
-				req.Header.Add(key, value)
+	if c.auth != nil {
+		req.Header.Set("Authorization", c.auth.Header())
+	}
+
+	for key, values := range headers {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return req, nil
+}
+
+// do sends the request and returns the response if the server answered with
+// a 2xx status code, or an *APIError otherwise. Transient failures are
+// retried according to the retry policy. The caller is responsible for
+// closing the response body
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if err == nil {
+			if s := resp.StatusCode; s >= 200 && s < 300 {
+				return resp, nil
 			}
+
+			err = responseError(resp)
+			resp.Body.Close()
+		}
+
+		delay, ok := c.retryPolicy.retryDelay(req, err, attempt)
+		if !ok {
+			return nil, err
+		}
+
+		if sleep(req.Context(), delay) != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// doJSON sends in, if not nil, as the JSON encoded request body and decodes
+// the response into out, if not nil
+func (c *Client) doJSON(ctx context.Context, method string, u *url.URL, in, out any, headers http.Header) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
 		}
+		body = bytes.NewReader(buf)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, method, u, body, headers)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
-	if s := resp.StatusCode; s < 200 || s >= 300 {
-		return nil, fmt.Errorf("non-200 http response code from server: %d", s)
+	resp, err := c.do(req)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	var pubResp PublishResult
-	if err = json.NewDecoder(resp.Body).Decode(&pubResp); err != nil {
-		return nil, err
+	if out == nil {
+		return nil
 	}
 
-	return &pubResp, nil
+	return json.NewDecoder(resp.Body).Decode(out)
 }
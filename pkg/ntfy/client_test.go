@@ -0,0 +1,110 @@
+package ntfy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *PublishOpts
+		wantPath    string
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:     "JSON encoding",
+			opts:     &PublishOpts{Message: &Message{Topic: "alerts", Message: "Disk full", Title: "Alert"}},
+			wantPath: "/",
+			wantBody: `{"topic":"alerts","message":"Disk full","title":"Alert"}`,
+			wantHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"X-Title":      "",
+			},
+		},
+		{
+			name: "Header encoding",
+			opts: &PublishOpts{
+				Message: &Message{
+					Topic:    "alerts",
+					Message:  "Disk full",
+					Title:    "Ünïcödé alert",
+					Tags:     []string{"warning"},
+					Priority: Max,
+				},
+				Headers:  http.Header{"x-cache": {"no"}},
+				Encoding: HeaderEncoding,
+			},
+			wantPath: "/alerts",
+			wantBody: "Disk full",
+			wantHeaders: map[string]string{
+				"Content-Type": "text/plain; charset=utf-8",
+				"X-Title":      "=?UTF-8?b?w5xuw69jw7Zkw6kgYWxlcnQ=?=",
+				"X-Tags":       "warning",
+				"X-Priority":   "5",
+				"X-Message":    "",
+				"X-Cache":      "no",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotPath   string
+				gotBody   string
+				gotHeader http.Header
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotHeader = r.Header
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+				fmt.Fprintln(w, `{"id":"m1","time":1,"event":"message","topic":"alerts"}`)
+			}))
+			defer srv.Close()
+
+			c, err := New(WithHost(srv.URL))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			res, err := c.Publish(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+
+			if res.ID != "m1" {
+				t.Errorf("unexpected result ID: got = %s, want = m1", res.ID)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("unexpected path: got = %s, want = %s", gotPath, tt.wantPath)
+			}
+			if gotBody != tt.wantBody {
+				t.Errorf("unexpected body: got = %s, want = %s", gotBody, tt.wantBody)
+			}
+			for key, want := range tt.wantHeaders {
+				if got := gotHeader.Get(key); got != want {
+					t.Errorf("unexpected %s header: got = %q, want = %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPublishValidation(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.Publish(context.Background(), &PublishOpts{Message: &Message{}}); err == nil {
+		t.Error("expected validation error for missing topic")
+	}
+}
@@ -0,0 +1,90 @@
+package ntfy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize limits how much of an error response body is read
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when the ntfy server answers with a non-2xx status
+// code. It can be matched against the sentinel errors with errors.Is
+type APIError struct {
+	Code     int    `json:"code"`           // ntfy error code, e.g. 40301
+	HTTPCode int    `json:"http"`           // HTTP status code of the response
+	Message  string `json:"error"`          // Description of the error
+	Link     string `json:"link,omitempty"` // Link to documentation about the error
+
+	RetryAfter time.Duration `json:"-"` // Delay requested by the server before retrying, if any
+}
+
+var (
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrTopicReserved      = errors.New("topic reserved")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	ErrMessageTooLarge    = errors.New("message too large")
+)
+
+// ntfy error codes that map to sentinel errors
+const (
+	codeTopicReserved      = 40902
+	codeAttachmentTooLarge = 41301
+)
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("non-200 http response code from server: %d", e.HTTPCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.Code != 0 {
+		msg += fmt.Sprintf(" (error code %d)", e.Code)
+	}
+
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.HTTPCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.HTTPCode == http.StatusForbidden
+	case ErrTopicReserved:
+		return e.Code == codeTopicReserved
+	case ErrRateLimited:
+		return e.HTTPCode == http.StatusTooManyRequests
+	case ErrAttachmentTooLarge:
+		return e.Code == codeAttachmentTooLarge
+	case ErrMessageTooLarge:
+		return e.HTTPCode == http.StatusRequestEntityTooLarge && e.Code != codeAttachmentTooLarge
+	}
+
+	return false
+}
+
+// responseError returns the error describing an unsuccessful response,
+// decoded from the JSON error body sent by the server if possible
+func responseError(resp *http.Response) error {
+	apiErr := &APIError{}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr = &APIError{Message: strings.TrimSpace(string(body))}
+	}
+
+	// The status code of the response takes precedence over the body
+	apiErr.HTTPCode = resp.StatusCode
+	apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	return apiErr
+}
@@ -0,0 +1,110 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	sentinels := []error{
+		ErrUnauthorized,
+		ErrForbidden,
+		ErrTopicReserved,
+		ErrRateLimited,
+		ErrAttachmentTooLarge,
+		ErrMessageTooLarge,
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *APIError
+		matches error
+	}{
+		{
+			name:    "Unauthorized",
+			status:  http.StatusUnauthorized,
+			body:    `{"code":40101,"http":401,"error":"unauthorized","link":"https://ntfy.sh/docs/publish/#authentication"}`,
+			want:    &APIError{Code: 40101, HTTPCode: 401, Message: "unauthorized", Link: "https://ntfy.sh/docs/publish/#authentication"},
+			matches: ErrUnauthorized,
+		},
+		{
+			name:    "Forbidden",
+			status:  http.StatusForbidden,
+			body:    `{"code":40301,"http":403,"error":"forbidden"}`,
+			want:    &APIError{Code: 40301, HTTPCode: 403, Message: "forbidden"},
+			matches: ErrForbidden,
+		},
+		{
+			name:    "Topic reserved",
+			status:  http.StatusConflict,
+			body:    `{"code":40902,"http":409,"error":"conflict: topic is already reserved"}`,
+			want:    &APIError{Code: 40902, HTTPCode: 409, Message: "conflict: topic is already reserved"},
+			matches: ErrTopicReserved,
+		},
+		{
+			name:    "Rate limited",
+			status:  http.StatusTooManyRequests,
+			body:    `{"code":42901,"http":429,"error":"limit reached: too many requests"}`,
+			want:    &APIError{Code: 42901, HTTPCode: 429, Message: "limit reached: too many requests"},
+			matches: ErrRateLimited,
+		},
+		{
+			name:    "Attachment too large",
+			status:  http.StatusRequestEntityTooLarge,
+			body:    `{"code":41301,"http":413,"error":"attachment too large"}`,
+			want:    &APIError{Code: 41301, HTTPCode: 413, Message: "attachment too large"},
+			matches: ErrAttachmentTooLarge,
+		},
+		{
+			name:    "Message too large",
+			status:  http.StatusRequestEntityTooLarge,
+			body:    `{"code":41303,"http":413,"error":"JSON body too large"}`,
+			want:    &APIError{Code: 41303, HTTPCode: 413, Message: "JSON body too large"},
+			matches: ErrMessageTooLarge,
+		},
+		{
+			name:   "Plain text body",
+			status: http.StatusBadGateway,
+			body:   "bad gateway\n",
+			want:   &APIError{HTTPCode: 502, Message: "bad gateway"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c, err := New(WithHost(srv.URL))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = c.Publish(context.Background(), &PublishOpts{Message: &Message{Topic: "t1"}})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T: %v", err, err)
+			}
+
+			if !reflect.DeepEqual(apiErr, tt.want) {
+				t.Errorf("unexpected error: got = %+v, want = %+v", apiErr, tt.want)
+			}
+
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == tt.matches; got != want {
+					t.Errorf("errors.Is(err, %v) = %v, want %v", sentinel, got, want)
+				}
+			}
+		})
+	}
+}
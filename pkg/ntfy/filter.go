@@ -0,0 +1,56 @@
+package ntfy
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Filter restricts the messages delivered by the server on subscriptions and polls
+type Filter struct {
+	ID         string     // Only the message with this ID
+	Message    string     // Only messages whose body exactly matches this string
+	Title      string     // Only messages whose title exactly matches this string
+	Priorities []Priority `validate:"dive,min=1,max=5"` // Only messages with any of these priorities
+	Tags       []string   // Only messages that have all of these tags
+}
+
+// PriorityRange returns all priorities between from and to, inclusive
+func PriorityRange(from, to Priority) []Priority {
+	var priorities []Priority
+	for p := from; p <= to; p++ {
+		priorities = append(priorities, p)
+	}
+	return priorities
+}
+
+// apply sets the query parameters of the filter
+func (f *Filter) apply(q url.Values) {
+	if f == nil {
+		return
+	}
+
+	if f.ID != "" {
+		q.Set("id", f.ID)
+	}
+
+	if f.Message != "" {
+		q.Set("message", f.Message)
+	}
+
+	if f.Title != "" {
+		q.Set("title", f.Title)
+	}
+
+	if len(f.Priorities) > 0 {
+		priorities := make([]string, len(f.Priorities))
+		for i, p := range f.Priorities {
+			priorities[i] = strconv.Itoa(int(p))
+		}
+		q.Set("priority", strings.Join(priorities, ","))
+	}
+
+	if len(f.Tags) > 0 {
+		q.Set("tags", strings.Join(f.Tags, ","))
+	}
+}
@@ -0,0 +1,78 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestFilterApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   url.Values
+	}{
+		{
+			name: "Nil filter",
+			want: url.Values{},
+		},
+		{
+			name:   "Empty filter",
+			filter: &Filter{},
+			want:   url.Values{},
+		},
+		{
+			name: "All fields",
+			filter: &Filter{
+				ID:         "abc",
+				Message:    "msg",
+				Title:      "title",
+				Priorities: []Priority{High, Max},
+				Tags:       []string{"prod", "db"},
+			},
+			want: url.Values{
+				"id":       {"abc"},
+				"message":  {"msg"},
+				"title":    {"title"},
+				"priority": {"4,5"},
+				"tags":     {"prod,db"},
+			},
+		},
+		{
+			name:   "Priority range",
+			filter: &Filter{Priorities: PriorityRange(Low, High)},
+			want:   url.Values{"priority": {"2,3,4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := url.Values{}
+			tt.filter.apply(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterValidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, _, err = c.Poll(context.Background(), &PollOpts{
+		Topics: []string{"t1"},
+		Filter: &Filter{Priorities: []Priority{Max + 1}},
+	})
+	if err == nil {
+		t.Error("expected validation error for out of range priority")
+	}
+}
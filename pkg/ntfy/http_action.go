@@ -12,7 +12,7 @@ type (
 		URL     *url.URL          // URL to which the HTTP request will be sent
 		Method  string            // HTTP method to use for request, default is POST
 		Headers map[string]string // HTTP headers to pass in request
-		Body    X                 // HTTP body
+		Body    X                 // HTTP body, JSON encoded
 		Clear   bool              // Clear notification after HTTP request succeeds
 	}
 
@@ -37,16 +37,9 @@ func (h *HttpAction[X]) MarshalJSON() ([]byte, error) {
 		url = h.URL.String()
 	}
 
-	body := ""
-
-	var zeroVal X
-	if h.Body != zeroVal {
-		b, err := json.Marshal(h.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		body = string(b)
+	body, err := h.jsonBody()
+	if err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(&httpAction{
@@ -59,3 +52,78 @@ func (h *HttpAction[X]) MarshalJSON() ([]byte, error) {
 		Body:    body,
 	})
 }
+
+func (h *HttpAction[X]) UnmarshalJSON(data []byte) error {
+	var aux httpAction
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	u, err := parseOptionalURL(aux.URL)
+	if err != nil {
+		return err
+	}
+
+	*h = HttpAction[X]{
+		Label:   aux.Label,
+		URL:     u,
+		Method:  aux.Method,
+		Headers: aux.Headers,
+		Clear:   aux.Clear,
+	}
+
+	if aux.Body == "" {
+		return nil
+	}
+
+	err = json.Unmarshal([]byte(aux.Body), &h.Body)
+	if s, ok := any(&h.Body).(*string); ok && err != nil {
+		// String bodies are JSON encoded by MarshalJSON, but bodies sent by
+		// other publishers are often plain text, which is kept as is
+		*s = aux.Body
+		return nil
+	}
+
+	return err
+}
+
+// MarshalText encodes the action in ntfy's short form, e.g.
+// http, Close door, https://api.example.com/door, method=PUT, clear=true.
+// Unlike in the JSON form, a string body is sent as is, so that bodies
+// parsed by ParseActions are encoded unchanged
+func (h *HttpAction[X]) MarshalText() ([]byte, error) {
+	body, err := h.jsonBody()
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := any(&h.Body).(*string); ok {
+		body = *s
+	}
+
+	var keyed []actionField
+	if h.Method != "" {
+		keyed = append(keyed, actionField{key: "method", value: h.Method})
+	}
+	keyed = append(keyed, mapFields("headers.", h.Headers)...)
+	if body != "" {
+		keyed = append(keyed, actionField{key: "body", value: body})
+	}
+
+	return formatAction("http", h.Label, h.URL, keyed, h.Clear)
+}
+
+// jsonBody returns the JSON encoded body, or an empty string if the body is
+// the zero value
+func (h *HttpAction[X]) jsonBody() (string, error) {
+	var zeroVal X
+	if h.Body == zeroVal {
+		return "", nil
+	}
+
+	b, err := json.Marshal(h.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -118,3 +118,23 @@ func TestHTTPActionMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPActionStringBody(t *testing.T) {
+	action := &HttpAction[string]{Label: "Send", URL: &url.URL{Scheme: "https", Host: "x"}, Body: "hello"}
+
+	gotJSON, err := json.Marshal(action)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"action":"http","label":"Send","url":"https://x","body":"\"hello\""}`; string(gotJSON) != want {
+		t.Errorf("json.Marshal() = %s, want %s", gotJSON, want)
+	}
+
+	gotText, err := action.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error = %v", err)
+	}
+	if want := "http, Send, https://x, body=hello"; string(gotText) != want {
+		t.Errorf("MarshalText() = %s, want %s", gotText, want)
+	}
+}
@@ -2,14 +2,19 @@ package ntfy
 
 import (
 	"encoding/json"
+	"mime"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type (
 	// Message is a struct you can create from TopicPublisher that
-	// will publish a message to the specified topic. This method does not allow
-	// for attaching files to the notification, but it can post a link to an attachment
+	// will publish a message to the specified topic. Publishing it as JSON does
+	// not allow for attaching files to the notification, but it can post a link
+	// to an attachment; use Client.PublishFile to upload a file instead
 	Message struct {
 		Topic    string         `validate:"required"` // Target topic name
 		Message  string         // Message body; set to triggered if empty or not passed
@@ -22,6 +27,7 @@ type (
 		Delay    time.Duration  // Duration to delay delivery
 		Email    string         // E-mail address for e-mail notifications
 		Call     string         // Phone number to use for voice call
+		Markdown bool           // Render the message body as Markdown
 
 		AttachURLFilename string   // File name of the attachment
 		AttachURL         *url.URL // URL of an attachment
@@ -41,6 +47,7 @@ type (
 		Call      string         `json:"call,omitempty"`
 		Filename  string         `json:"filename,omitempty"`
 		AttachURL string         `json:"attachurl,omitempty"`
+		Markdown  bool           `json:"markdown,omitempty"`
 	}
 )
 
@@ -84,5 +91,73 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		Call:      m.Call,
 		Filename:  m.AttachURLFilename,
 		AttachURL: attachURL,
+		Markdown:  m.Markdown,
 	})
 }
+
+// headers returns the message fields encoded as ntfy X-* headers, for
+// requests in which the body is not the JSON encoded message. Values that
+// are not printable ASCII are encoded as defined in RFC 2047
+func (m *Message) headers() (http.Header, error) {
+	h := http.Header{}
+	err := m.fields(func(key, value string) {
+		h.Set("X-"+key, mime.BEncoding.Encode("UTF-8", value))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// fields calls set with the name and value of every non-empty field of the
+// message, as understood by the header and query parameter publishing APIs
+func (m *Message) fields(set func(key, value string)) error {
+	field := func(key, value string) {
+		if value != "" {
+			set(key, value)
+		}
+	}
+
+	field("Message", m.Message)
+	field("Title", m.Title)
+	field("Tags", strings.Join(m.Tags, ","))
+
+	if m.Priority > 0 {
+		field("Priority", strconv.Itoa(int(m.Priority)))
+	}
+
+	if len(m.Actions) > 0 {
+		actions, err := FormatActions(m.Actions)
+		if err != nil {
+			return err
+		}
+		field("Actions", actions)
+	}
+
+	if m.ClickURL != nil {
+		field("Click", m.ClickURL.String())
+	}
+
+	if m.IconURL != nil {
+		field("Icon", m.IconURL.String())
+	}
+
+	if m.AttachURL != nil {
+		field("Attach", m.AttachURL.String())
+	}
+
+	if m.Delay > 0 {
+		field("Delay", m.Delay.String())
+	}
+
+	field("Email", m.Email)
+	field("Call", m.Call)
+	field("Filename", m.AttachURLFilename)
+
+	if m.Markdown {
+		field("Markdown", "yes")
+	}
+
+	return nil
+}
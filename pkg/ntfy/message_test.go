@@ -2,6 +2,7 @@ package ntfy
 
 import (
 	"encoding/json"
+	"net/http"
 	"net/url"
 	"reflect"
 	"testing"
@@ -97,6 +98,11 @@ func TestMessageMarshalJSON(t *testing.T) {
 			arg:      Message{AttachURL: &url.URL{Scheme: "https", Host: "example.com"}},
 			expected: message{Topic: "", AttachURL: "https://example.com"},
 		},
+		{
+			name:     "Markdown Field",
+			arg:      Message{Markdown: true},
+			expected: message{Topic: "", Markdown: true},
+		},
 		{
 			name: "All Fields",
 			arg: Message{
@@ -183,3 +189,71 @@ func TestMessageMarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arg      Message
+		expected http.Header
+	}{
+		{
+			name:     "Empty Message",
+			expected: http.Header{},
+		},
+		{
+			name:     "Negative Priority",
+			arg:      Message{Priority: -1},
+			expected: http.Header{},
+		},
+		{
+			name: "All Fields",
+			arg: Message{
+				Topic:    "Topic",
+				Message:  "Message",
+				Title:    "Title",
+				Tags:     []string{"tag1", "tag2"},
+				Priority: 4,
+				Actions: []ActionButton{&ViewAction{
+					Label: "view",
+					Link:  &url.URL{Scheme: "https", Host: "example.com"},
+				}},
+				ClickURL:          &url.URL{Scheme: "https", Host: "example.com", Path: "/click"},
+				IconURL:           &url.URL{Scheme: "https", Host: "example.com", Path: "/icon"},
+				Delay:             time.Minute,
+				Email:             "email@example.com",
+				Call:              "1234567890",
+				AttachURLFilename: "file.txt",
+				AttachURL:         &url.URL{Scheme: "https", Host: "example.com", Path: "/file"},
+				Markdown:          true,
+			},
+			expected: http.Header{
+				"X-Message":  {"Message"},
+				"X-Title":    {"Title"},
+				"X-Tags":     {"tag1,tag2"},
+				"X-Priority": {"4"},
+				"X-Actions":  {"view, view, https://example.com"},
+				"X-Click":    {"https://example.com/click"},
+				"X-Icon":     {"https://example.com/icon"},
+				"X-Attach":   {"https://example.com/file"},
+				"X-Delay":    {"1m0s"},
+				"X-Email":    {"email@example.com"},
+				"X-Call":     {"1234567890"},
+				"X-Filename": {"file.txt"},
+				"X-Markdown": {"yes"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.arg.headers()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("unexpected result:\n got  = %v\n want = %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package ntfy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOutboxMaxEntries is the spool size used when none is configured
+const DefaultOutboxMaxEntries = 1000
+
+// outboxExt is the file extension of spooled entries
+const outboxExt = ".json"
+
+type (
+	// Outbox publishes messages through a Client and spools them to a local
+	// directory when the server cannot be reached. Spooled messages survive
+	// process restarts and are delivered in order once the server is back.
+	// Each message is stored in its own file, named after its sequence number
+	Outbox struct {
+		client  *Client
+		dir     string
+		options *OutboxOptions
+
+		mu      sync.Mutex
+		entries []string // File names of spooled entries, oldest first
+		seq     uint64
+	}
+
+	OutboxOptions struct {
+		MaxEntries   int             // Maximum number of spooled messages; the oldest are dropped first
+		TTL          time.Duration   // Spooled messages older than this are dropped instead of delivered
+		OnFlushError func(err error) // Called by Run and Publish when flushing dropped messages or failed
+	}
+
+	OutboxOption func(*OutboxOptions)
+
+	// outboxEntry is the on-disk representation of a spooled message
+	outboxEntry struct {
+		Queued  int64           `json:"queued"`  // Unix time stamp at which the message was spooled
+		Message json.RawMessage `json:"message"` // JSON encoded message
+	}
+)
+
+func WithMaxEntries(n int) OutboxOption {
+	return func(o *OutboxOptions) {
+		o.MaxEntries = n
+	}
+}
+
+func WithTTL(ttl time.Duration) OutboxOption {
+	return func(o *OutboxOptions) {
+		o.TTL = ttl
+	}
+}
+
+func WithOnFlushError(fn func(err error)) OutboxOption {
+	return func(o *OutboxOptions) {
+		o.OnFlushError = fn
+	}
+}
+
+// NewOutbox creates an outbox spooling to the given directory, which is
+// created if needed. Messages spooled by a previous process are kept
+func NewOutbox(client *Client, dir string, options ...OutboxOption) (*Outbox, error) {
+	o := &OutboxOptions{
+		MaxEntries: DefaultOutboxMaxEntries,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ob := &Outbox{client: client, dir: dir, options: o}
+	for _, f := range files {
+		name := f.Name()
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxExt), 10, 64)
+		if f.IsDir() || !strings.HasSuffix(name, outboxExt) || err != nil {
+			continue
+		}
+
+		ob.entries = append(ob.entries, name)
+		ob.seq = max(ob.seq, seq)
+	}
+	sort.Strings(ob.entries)
+
+	return ob, nil
+}
+
+// Publish delivers the message, or spools it if the server cannot be
+// reached or answers with a transient error. Messages are also spooled
+// while older messages are waiting, so that delivery order is preserved.
+// It returns nil if the message was delivered or spooled; errors of older
+// messages dropped meanwhile are reported through the OnFlushError hook
+func (ob *Outbox) Publish(ctx context.Context, m *Message) error {
+	if err := ob.client.validator.Struct(m); err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if len(ob.entries) == 0 {
+		err := ob.send(ctx, buf)
+		if err == nil || !isTransient(err) {
+			return err
+		}
+
+		return ob.spool(buf)
+	}
+
+	if err := ob.spool(buf); err != nil {
+		return err
+	}
+
+	// Errors of other messages dropped while flushing must not be mistaken
+	// for a failure to publish this one
+	ob.reportFlushError(ob.flush(ctx))
+
+	return nil
+}
+
+// Flush delivers spooled messages in order. It stops at the first transient
+// failure, leaving the remaining messages spooled. Messages rejected by the
+// server with a permanent error are dropped, and their errors returned
+func (ob *Outbox) Flush(ctx context.Context) error {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	return ob.flush(ctx)
+}
+
+// Run flushes the outbox at the given interval until the context is
+// cancelled. Errors of messages rejected by the server, which are dropped,
+// are reported through the OnFlushError hook
+func (ob *Outbox) Run(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			ob.reportFlushError(ob.Flush(ctx))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of spooled messages
+func (ob *Outbox) Len() int {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	return len(ob.entries)
+}
+
+func (ob *Outbox) flush(ctx context.Context) error {
+	var errs []error
+	for len(ob.entries) > 0 {
+		name := ob.entries[0]
+
+		entry, err := ob.read(name)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("dropping unreadable outbox entry %s: %w", name, err))
+		case ob.options.TTL > 0 && time.Since(time.Unix(entry.Queued, 0)) > ob.options.TTL:
+			// Expired messages are dropped without being delivered
+		default:
+			if err := ob.send(ctx, entry.Message); err != nil {
+				if isTransient(err) || ctx.Err() != nil {
+					return errors.Join(errs...)
+				}
+				errs = append(errs, err)
+			}
+		}
+
+		if err := ob.remove(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reportFlushError passes a non-nil flush error to the OnFlushError hook
+func (ob *Outbox) reportFlushError(err error) {
+	if err != nil && ob.options.OnFlushError != nil {
+		ob.options.OnFlushError(err)
+	}
+}
+
+// send publishes a JSON encoded message. The response body is not decoded,
+// since a message accepted by the server must never be spooled again
+func (ob *Outbox) send(ctx context.Context, buf []byte) error {
+	req, err := ob.client.newRequest(ctx, http.MethodPost, ob.client.host, bytes.NewReader(buf), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := ob.client.do(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// spool appends the message to the outbox, dropping the oldest messages if
+// the outbox is full. The entry is written to a temporary file and renamed,
+// so that a crash never leaves a partial entry behind
+func (ob *Outbox) spool(buf []byte) error {
+	data, err := json.Marshal(&outboxEntry{Queued: time.Now().Unix(), Message: buf})
+	if err != nil {
+		return err
+	}
+
+	for ob.options.MaxEntries > 0 && len(ob.entries) >= ob.options.MaxEntries {
+		if err := ob.remove(); err != nil {
+			return err
+		}
+	}
+
+	ob.seq++
+	name := fmt.Sprintf("%020d%s", ob.seq, outboxExt)
+
+	tmp, err := os.CreateTemp(ob.dir, ".spool-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(ob.dir, name)); err != nil {
+		return err
+	}
+
+	ob.entries = append(ob.entries, name)
+	return nil
+}
+
+func (ob *Outbox) read(name string) (*outboxEntry, error) {
+	data, err := os.ReadFile(filepath.Join(ob.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry outboxEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// remove deletes the oldest spooled entry
+func (ob *Outbox) remove() error {
+	if err := os.Remove(filepath.Join(ob.dir, ob.entries[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	ob.entries = ob.entries[1:]
+	return nil
+}
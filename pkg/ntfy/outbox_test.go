@@ -0,0 +1,268 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// outboxServer records delivered messages and answers with a configurable status
+type outboxServer struct {
+	mu        sync.Mutex
+	status    int
+	delivered []string
+}
+
+func (s *outboxServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	var m message
+	json.NewDecoder(r.Body).Decode(&m)
+	s.delivered = append(s.delivered, m.Message)
+	json.NewEncoder(w).Encode(PublishResult{ID: m.Message, Topic: m.Topic})
+}
+
+func (s *outboxServer) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func newOutboxTest(t *testing.T, status int) (*outboxServer, *Client) {
+	t.Helper()
+
+	s := &outboxServer{status: status}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return s, c
+}
+
+func TestOutboxSpoolAndFlush(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, c := newOutboxTest(t, http.StatusServiceUnavailable)
+
+	ob, err := NewOutbox(c, dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	for _, body := range []string{"m1", "m2"} {
+		if err := ob.Publish(ctx, &Message{Topic: "t1", Message: body}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	if got := ob.Len(); got != 2 {
+		t.Fatalf("unexpected spool size: got = %d, want = 2", got)
+	}
+
+	// Spooled messages are loaded by a new outbox on the same directory
+	ob, err = NewOutbox(c, dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	if got := ob.Len(); got != 2 {
+		t.Fatalf("unexpected spool size after reopening: got = %d, want = 2", got)
+	}
+
+	s.setStatus(http.StatusOK)
+
+	// New messages are delivered after the spooled ones
+	if err := ob.Publish(ctx, &Message{Topic: "t1", Message: "m3"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if want := []string{"m1", "m2", "m3"}; !reflect.DeepEqual(s.delivered, want) {
+		t.Errorf("unexpected delivery order: got = %v, want = %v", s.delivered, want)
+	}
+
+	if got := ob.Len(); got != 0 {
+		t.Errorf("unexpected spool size after flush: got = %d, want = 0", got)
+	}
+}
+
+func TestOutboxLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Max entries", func(t *testing.T) {
+		s, c := newOutboxTest(t, http.StatusBadGateway)
+		ob, err := NewOutbox(c, t.TempDir(), WithMaxEntries(2))
+		if err != nil {
+			t.Fatalf("NewOutbox() error = %v", err)
+		}
+
+		for _, body := range []string{"m1", "m2", "m3"} {
+			if err := ob.Publish(ctx, &Message{Topic: "t1", Message: body}); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+
+		s.setStatus(http.StatusOK)
+		if err := ob.Flush(ctx); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		if want := []string{"m2", "m3"}; !reflect.DeepEqual(s.delivered, want) {
+			t.Errorf("unexpected delivered messages: got = %v, want = %v", s.delivered, want)
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		s, c := newOutboxTest(t, http.StatusBadGateway)
+		ob, err := NewOutbox(c, t.TempDir(), WithTTL(time.Nanosecond))
+		if err != nil {
+			t.Fatalf("NewOutbox() error = %v", err)
+		}
+
+		if err := ob.Publish(ctx, &Message{Topic: "t1", Message: "m1"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+
+		time.Sleep(time.Millisecond)
+		s.setStatus(http.StatusOK)
+		if err := ob.Flush(ctx); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		if len(s.delivered) != 0 || ob.Len() != 0 {
+			t.Errorf("expected expired message to be dropped, delivered = %v, spooled = %d", s.delivered, ob.Len())
+		}
+	})
+}
+
+func TestOutboxPermanentError(t *testing.T) {
+	_, c := newOutboxTest(t, http.StatusForbidden)
+	ob, err := NewOutbox(c, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	err = ob.Publish(context.Background(), &Message{Topic: "t1", Message: "m1"})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("unexpected error: got = %v, want = %v", err, ErrForbidden)
+	}
+
+	if got := ob.Len(); got != 0 {
+		t.Errorf("unexpected spool size: got = %d, want = 0", got)
+	}
+}
+
+func TestOutboxUndecodableResponse(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ob, err := NewOutbox(c, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	if err := ob.Publish(context.Background(), &Message{Topic: "t1", Message: "m1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if got := ob.Len(); got != 0 {
+		t.Errorf("unexpected spool size: got = %d, want = 0", got)
+	}
+	if requests != 1 {
+		t.Errorf("unexpected number of requests: got = %d, want = 1", requests)
+	}
+}
+
+func TestOutboxRunReportsErrors(t *testing.T) {
+	s, c := newOutboxTest(t, http.StatusServiceUnavailable)
+
+	errs := make(chan error, 1)
+	ob, err := NewOutbox(c, t.TempDir(), WithOnFlushError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	if err := ob.Publish(context.Background(), &Message{Topic: "t1", Message: "m1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	s.setStatus(http.StatusBadRequest)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go ob.Run(ctx, 5*time.Millisecond)
+
+	select {
+	case err := <-errs:
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.HTTPCode != http.StatusBadRequest {
+			t.Errorf("unexpected error: got = %v, want status 400", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("rejected message was not reported")
+	}
+
+	if got := ob.Len(); got != 0 {
+		t.Errorf("unexpected spool size: got = %d, want = 0", got)
+	}
+}
+
+func TestOutboxPublishIgnoresDroppedEntries(t *testing.T) {
+	s, c := newOutboxTest(t, http.StatusServiceUnavailable)
+
+	var flushErrs []error
+	ob, err := NewOutbox(c, t.TempDir(), WithOnFlushError(func(err error) {
+		flushErrs = append(flushErrs, err)
+	}))
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	if err := ob.Publish(context.Background(), &Message{Topic: "t1", Message: "m1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// The spooled message is rejected while flushing before the new one
+	s.setStatus(http.StatusBadRequest)
+
+	if err := ob.Publish(context.Background(), &Message{Topic: "t1", Message: "m2"}); err != nil {
+		t.Errorf("Publish() error = %v, want nil once the message is spooled", err)
+	}
+
+	if len(flushErrs) != 1 {
+		t.Fatalf("unexpected number of reported flush errors: got = %d, want = 1", len(flushErrs))
+	}
+
+	var apiErr *APIError
+	if !errors.As(flushErrs[0], &apiErr) || apiErr.HTTPCode != http.StatusBadRequest {
+		t.Errorf("unexpected flush error: got = %v, want status 400", flushErrs[0])
+	}
+}
@@ -0,0 +1,68 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// PollOpts configures a one-shot request for the cached messages of one or more topics
+type PollOpts struct {
+	Topics    []string    `validate:"required,min=1,dive,required"`
+	Since     Since       `validate:"-"` // Cursor selecting the returned messages
+	Scheduled bool        // Include scheduled messages that are not yet delivered
+	Filter    *Filter     // Only return messages matching the filter
+	Headers   http.Header `validate:"-"`
+}
+
+// Poll returns the cached messages of the given topics without holding a
+// connection open. It also returns the cursor to pass as Since on the next
+// call to receive only newer messages
+func (c *Client) Poll(ctx context.Context, opts *PollOpts) ([]ReceivedMessage, Since, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, Since{}, err
+	}
+
+	u := c.endpoint(strings.Join(opts.Topics, ","), "json")
+	q := u.Query()
+	q.Set("poll", "1")
+	if !opts.Since.IsZero() {
+		q.Set("since", opts.Since.String())
+	}
+	if opts.Scheduled {
+		q.Set("scheduled", "1")
+	}
+	opts.Filter.apply(q)
+	u.RawQuery = q.Encode()
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil, opts.Headers)
+	if err != nil {
+		return nil, Since{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, Since{}, err
+	}
+	defer resp.Body.Close()
+
+	var messages []ReceivedMessage
+	err = readJSONStream(resp.Body, func(m *ReceivedMessage) bool {
+		if m.Event == MessageEvent {
+			messages = append(messages, *m)
+		}
+		return true
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, Since{}, err
+	}
+
+	next := opts.Since
+	if len(messages) > 0 {
+		next = SinceID(messages[len(messages)-1].ID)
+	}
+
+	return messages, next, nil
+}
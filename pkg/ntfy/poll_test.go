@@ -0,0 +1,105 @@
+package ntfy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSince(t *testing.T) {
+	tests := []struct {
+		name  string
+		since Since
+		want  string
+	}{
+		{name: "Zero", since: Since{}, want: ""},
+		{name: "All", since: SinceAll, want: "all"},
+		{name: "Latest", since: SinceLatest, want: "latest"},
+		{name: "Duration", since: SinceDuration(90 * time.Minute), want: "1h30m0s"},
+		{name: "Time", since: SinceTime(time.Unix(1700000000, 0)), want: "1700000000"},
+		{name: "ID", since: SinceID("abc123"), want: "abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.since.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := tt.since.IsZero(); got != (tt.want == "") {
+				t.Errorf("IsZero() = %v, want %v", got, tt.want == "")
+			}
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	var gotURL *url.URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL
+		fmt.Fprintln(w, `{"id":"m1","time":1,"event":"message","topic":"t1","message":"one"}`)
+		fmt.Fprintln(w, `{"id":"m2","time":2,"event":"message","topic":"t2","message":"two"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	messages, next, err := c.Poll(context.Background(), &PollOpts{
+		Topics:    []string{"t1", "t2"},
+		Since:     SinceAll,
+		Scheduled: true,
+	})
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	if gotURL.Path != "/t1,t2/json" {
+		t.Errorf("unexpected path: got = %s, want = /t1,t2/json", gotURL.Path)
+	}
+	wantQuery := url.Values{"poll": {"1"}, "since": {"all"}, "scheduled": {"1"}}
+	if !reflect.DeepEqual(gotURL.Query(), wantQuery) {
+		t.Errorf("unexpected query: got = %v, want = %v", gotURL.Query(), wantQuery)
+	}
+
+	want := []ReceivedMessage{
+		{ID: "m1", Time: 1, Event: MessageEvent, Topic: "t1", Message: "one"},
+		{ID: "m2", Time: 2, Event: MessageEvent, Topic: "t2", Message: "two"},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("unexpected messages:\n got  = %+v\n want = %+v", messages, want)
+	}
+
+	if next != SinceID("m2") {
+		t.Errorf("unexpected cursor: got = %v, want = %v", next, SinceID("m2"))
+	}
+}
+
+func TestPollEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	since := SinceID("m2")
+	messages, next, err := c.Poll(context.Background(), &PollOpts{Topics: []string{"t1"}, Since: since})
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	if len(messages) != 0 {
+		t.Errorf("expected no messages, got %+v", messages)
+	}
+	if next != since {
+		t.Errorf("unexpected cursor: got = %v, want = %v", next, since)
+	}
+}
@@ -1,5 +1,11 @@
 package ntfy
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Priority is an enum for the message priority
 type Priority int8
 
@@ -11,3 +17,25 @@ const (
 	High
 	Max
 )
+
+// ErrInvalidPriority is returned when a priority cannot be parsed
+var ErrInvalidPriority = errors.New("invalid priority")
+
+// ParsePriority parses a priority given as a number between 1 and 5 or as
+// one of the names min, low, default, high, max and urgent
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "min":
+		return Min, nil
+	case "2", "low":
+		return Low, nil
+	case "3", "default":
+		return Default, nil
+	case "4", "high":
+		return High, nil
+	case "5", "max", "urgent":
+		return Max, nil
+	}
+
+	return UnspecifiedPriority, fmt.Errorf("%w: %q", ErrInvalidPriority, s)
+}
@@ -0,0 +1,34 @@
+package ntfy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePriority(t *testing.T) {
+	testCases := []struct {
+		arg         string
+		expected    Priority
+		expectedErr error
+	}{
+		{arg: "1", expected: Min},
+		{arg: "low", expected: Low},
+		{arg: " Default ", expected: Default},
+		{arg: "4", expected: High},
+		{arg: "urgent", expected: Max},
+		{arg: "6", expectedErr: ErrInvalidPriority},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.arg, func(t *testing.T) {
+			actual, err := ParsePriority(tc.arg)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if actual != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
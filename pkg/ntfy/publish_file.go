@@ -0,0 +1,39 @@
+package ntfy
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// PublishFile uploads the contents of r as an attachment to the given topic.
+// The body is streamed with a PUT request, and the fields of the optional
+// message are sent as X-* headers. Message.Topic is ignored in favour of topic
+func (c *Client) PublishFile(ctx context.Context, topic string, r io.Reader, filename string, m *Message) (*PublishResult, error) {
+	if err := c.validator.Var(topic, "required"); err != nil {
+		return nil, err
+	}
+
+	// The filename is encoded like every other message field, on a copy so
+	// that the caller's message is left unchanged
+	msg := Message{}
+	if m != nil {
+		msg = *m
+	}
+	if filename != "" {
+		msg.AttachURLFilename = filename
+	}
+
+	headers, err := msg.headers()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, c.endpoint(topic), r, headers)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Content-Type")
+
+	return c.publish(req)
+}
@@ -0,0 +1,106 @@
+package ntfy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPublishFile(t *testing.T) {
+	var (
+		gotMethod string
+		gotPath   string
+		gotBody   string
+		gotHeader http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotHeader = r.Header
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		fmt.Fprintln(w, `{"id":"m1","time":1,"event":"message","topic":"builds"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	res, err := c.PublishFile(context.Background(), "builds", strings.NewReader("build log"), "build.log", &Message{
+		Title:             "Build failed",
+		Tags:              []string{"warning"},
+		Priority:          High,
+		AttachURLFilename: "ignored.log",
+	})
+	if err != nil {
+		t.Fatalf("PublishFile() error = %v", err)
+	}
+
+	if res.ID != "m1" {
+		t.Errorf("unexpected result ID: got = %s, want = m1", res.ID)
+	}
+	if gotMethod != http.MethodPut || gotPath != "/builds" {
+		t.Errorf("unexpected request: got = %s %s, want = PUT /builds", gotMethod, gotPath)
+	}
+	if gotBody != "build log" {
+		t.Errorf("unexpected body: got = %q, want = %q", gotBody, "build log")
+	}
+
+	wantHeaders := map[string]string{
+		"X-Filename":   "build.log",
+		"X-Title":      "Build failed",
+		"X-Tags":       "warning",
+		"X-Priority":   "4",
+		"Content-Type": "",
+	}
+	for key, want := range wantHeaders {
+		if got := gotHeader.Get(key); got != want {
+			t.Errorf("unexpected %s header: got = %q, want = %q", key, got, want)
+		}
+	}
+}
+
+func TestPublishFileMissingTopic(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.PublishFile(context.Background(), "", strings.NewReader(""), "", nil); err == nil {
+		t.Error("expected validation error for missing topic")
+	}
+}
+
+func TestPublishFileEncodedFilename(t *testing.T) {
+	var gotFilename string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFilename = r.Header.Get("X-Filename")
+		fmt.Fprintln(w, `{"id":"m1","time":1,"event":"message","topic":"docs"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	m := &Message{Title: "Report"}
+	if _, err := c.PublishFile(context.Background(), "docs", strings.NewReader("pdf"), "Bericht für März.pdf", m); err != nil {
+		t.Fatalf("PublishFile() error = %v", err)
+	}
+
+	if want := mime.BEncoding.Encode("UTF-8", "Bericht für März.pdf"); gotFilename != want {
+		t.Errorf("unexpected X-Filename header: got = %q, want = %q", gotFilename, want)
+	}
+	if m.AttachURLFilename != "" {
+		t.Errorf("PublishFile() modified the message: AttachURLFilename = %q", m.AttachURLFilename)
+	}
+}
@@ -0,0 +1,38 @@
+package ntfy
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PublishURL returns a URL that publishes the message when requested with a
+// plain GET, for tools that can only call a fixed URL. All message fields are
+// encoded as query parameters, and the client credentials, if any, are added
+// as the auth parameter. Note that anyone knowing the URL can publish with
+// these credentials
+func (c *Client) PublishURL(m *Message) (string, error) {
+	if err := c.validator.Var(m, "required"); err != nil {
+		return "", err
+	}
+
+	if err := c.validator.Var(m.Topic, "required"); err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	err := m.fields(func(key, value string) {
+		q.Set(strings.ToLower(key), value)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if auth := c.AuthQueryParam(); auth != "" {
+		q.Set("auth", auth)
+	}
+
+	u := c.endpoint(m.Topic, "publish")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
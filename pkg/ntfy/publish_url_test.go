@@ -0,0 +1,68 @@
+package ntfy
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPublishURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []Option
+		arg         *Message
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "Topic Only",
+			opts:     []Option{WithHost("https://ntfy.example.com")},
+			arg:      &Message{Topic: "printer"},
+			expected: "https://ntfy.example.com/printer/publish",
+		},
+		{
+			name: "Escaped Fields",
+			opts: []Option{WithHost("https://ntfy.example.com")},
+			arg: &Message{
+				Topic:    "printer",
+				Message:  "Toner low & paper jam: 100%",
+				Title:    "Büro #2",
+				Tags:     []string{"warning", "printer"},
+				Priority: High,
+				ClickURL: &url.URL{Scheme: "http", Host: "printer.local", Path: "/status", RawQuery: "a=1&b=2"},
+				Delay:    30 * time.Minute,
+			},
+			expected: "https://ntfy.example.com/printer/publish?click=http%3A%2F%2Fprinter.local%2Fstatus%3Fa%3D1%26b%3D2" +
+				"&delay=30m0s&message=Toner+low+%26+paper+jam%3A+100%25&priority=4&tags=warning%2Cprinter&title=B%C3%BCro+%232",
+		},
+		{
+			name:     "Credentials",
+			opts:     []Option{WithHost("https://ntfy.example.com"), WithToken("tk_abc")},
+			arg:      &Message{Topic: "router", Message: "WAN down"},
+			expected: "https://ntfy.example.com/router/publish?auth=QmVhcmVyIHRrX2FiYw&message=WAN+down",
+		},
+		{
+			name:        "Missing Topic",
+			arg:         &Message{Message: "no topic"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			actual, err := c.PublishURL(tc.arg)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("PublishURL() error = %v, expectedErr %v", err, tc.expectedErr)
+			}
+
+			if actual != tc.expected {
+				t.Errorf("unexpected URL:\ngot  = %s\nwant = %s", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package ntfy
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event is the type of event sent by the ntfy server on a subscription
+type Event string
+
+const (
+	OpenEvent        Event = "open"         // Sent when a subscription is established
+	KeepaliveEvent   Event = "keepalive"    // Sent periodically to keep the connection alive
+	MessageEvent     Event = "message"      // Sent when a message is published to a topic
+	PollRequestEvent Event = "poll_request" // Sent to instruct clients to poll for new messages
+)
+
+type (
+	// ReceivedMessage is a message or event received from the ntfy server
+	ReceivedMessage struct {
+		ID         string         `json:"id"`                   // Randomly chosen message identifier
+		Time       int64          `json:"time"`                 // Message date time, as Unix time stamp
+		Expires    int64          `json:"expires,omitempty"`    // Unix time stamp indicating when the message will be deleted
+		Event      Event          `json:"event"`                // Type of the event
+		Topic      string         `json:"topic"`                // Topic the message was published to
+		Message    string         `json:"message,omitempty"`    // Message body
+		Title      string         `json:"title,omitempty"`      // Message title
+		Tags       []string       `json:"tags,omitempty"`       // List of tags that may or not map to emojis
+		Priority   Priority       `json:"priority,omitempty"`   // Message priority with 1=min, 3=default and 5=max
+		Click      string         `json:"click,omitempty"`      // Website opened when notification is clicked
+		Icon       string         `json:"icon,omitempty"`       // URL to use as notification icon
+		Attachment *Attachment    `json:"attachment,omitempty"` // File attached to the message
+		Actions    []ActionButton `json:"actions,omitempty"`    // Action buttons of the notification
+	}
+
+	// Attachment describes a file attached to a received message
+	Attachment struct {
+		Name    string `json:"name"`              // Name of the attachment
+		Type    string `json:"type,omitempty"`    // Mime type of the attachment, only for uploaded files
+		Size    int64  `json:"size,omitempty"`    // Size of the attachment in bytes, only for uploaded files
+		Expires int64  `json:"expires,omitempty"` // Unix time stamp at which the attachment will be deleted
+		URL     string `json:"url"`               // URL of the attachment
+	}
+)
+
+// UnmarshalJSON decodes the message, including its action buttons into
+// *ViewAction, *HttpAction[string] and *BroadcastAction values. Action
+// types that are not supported by this package are skipped
+func (m *ReceivedMessage) UnmarshalJSON(data []byte) error {
+	type alias ReceivedMessage
+	aux := struct {
+		*alias
+		Actions []json.RawMessage `json:"actions,omitempty"`
+	}{alias: (*alias)(m)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	m.Actions = nil
+	for _, raw := range aux.Actions {
+		a, err := unmarshalAction(raw)
+		if err != nil {
+			return err
+		}
+
+		if a != nil {
+			m.Actions = append(m.Actions, a)
+		}
+	}
+
+	return nil
+}
+
+// unmarshalAction decodes an action button based on its action field. It
+// returns nil if the action type is unknown
+func unmarshalAction(data []byte) (ActionButton, error) {
+	var head struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	var a ActionButton
+	switch head.Action {
+	case "view":
+		a = &ViewAction{}
+	case "http":
+		a = &HttpAction[string]{}
+	case "broadcast":
+		a = &BroadcastAction{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("invalid %s action: %w", head.Action, err)
+	}
+
+	return a, nil
+}
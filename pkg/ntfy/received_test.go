@@ -0,0 +1,143 @@
+package ntfy
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestReceivedMessageUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    ReceivedMessage
+		wantErr bool
+	}{
+		{
+			name: "Keepalive",
+			arg:  `{"id":"k1","time":1,"event":"keepalive","topic":"t1"}`,
+			want: ReceivedMessage{ID: "k1", Time: 1, Event: KeepaliveEvent, Topic: "t1"},
+		},
+		{
+			name: "Attachment",
+			arg: `{"id":"m1","time":1,"expires":2,"event":"message","topic":"t1","message":"log",` +
+				`"attachment":{"name":"build.log","type":"text/plain","size":42,"expires":3,"url":"https://x/file/m1.txt"}}`,
+			want: ReceivedMessage{
+				ID:      "m1",
+				Time:    1,
+				Expires: 2,
+				Event:   MessageEvent,
+				Topic:   "t1",
+				Message: "log",
+				Attachment: &Attachment{
+					Name:    "build.log",
+					Type:    "text/plain",
+					Size:    42,
+					Expires: 3,
+					URL:     "https://x/file/m1.txt",
+				},
+			},
+		},
+		{
+			name: "Actions",
+			arg: `{"id":"m2","time":1,"event":"message","topic":"t1","actions":[` +
+				`{"id":"a1","action":"view","label":"Open","url":"https://x","clear":true},` +
+				`{"id":"a2","action":"http","label":"Close","url":"https://api/door","method":"PUT","headers":{"Authorization":"Bearer x"},"body":"{\"a\":1}"},` +
+				`{"id":"a3","action":"broadcast","label":"Picture","intent":"io.heckel.ntfy.USER_ACTION","extras":{"cmd":"pic"}},` +
+				`{"id":"a4","action":"copy","label":"Copy","value":"x"}]}`,
+			want: ReceivedMessage{
+				ID:    "m2",
+				Time:  1,
+				Event: MessageEvent,
+				Topic: "t1",
+				Actions: []ActionButton{
+					&ViewAction{Label: "Open", Link: &url.URL{Scheme: "https", Host: "x"}, Clear: true},
+					&HttpAction[string]{
+						Label:   "Close",
+						URL:     &url.URL{Scheme: "https", Host: "api", Path: "/door"},
+						Method:  "PUT",
+						Headers: map[string]string{"Authorization": "Bearer x"},
+						Body:    `{"a":1}`,
+					},
+					&BroadcastAction{Label: "Picture", Intent: DefaultBroadcastIntent, Extras: map[string]string{"cmd": "pic"}},
+				},
+			},
+		},
+		{
+			name:    "Invalid action URL",
+			arg:     `{"id":"m3","actions":[{"action":"view","label":"Open","url":"://"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ReceivedMessage
+			err := json.Unmarshal([]byte(tt.arg), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPActionUnmarshalJSON(t *testing.T) {
+	type payload struct {
+		A int `json:"a"`
+	}
+
+	var h HttpAction[payload]
+	err := json.Unmarshal([]byte(`{"action":"http","label":"Send","body":"{\"a\":1}"}`), &h)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if h.Label != "Send" || h.Body.A != 1 {
+		t.Errorf("unexpected action: %+v", h)
+	}
+}
+
+func TestHTTPActionStringBodyRoundTrip(t *testing.T) {
+	original := &Message{
+		Topic:   "hooks",
+		Actions: []ActionButton{&HttpAction[string]{Label: "Send", URL: &url.URL{Scheme: "https", Host: "x"}, Body: "hi"}},
+	}
+
+	buf, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	// Decode and re-publish the actions twice, as a webhook proxy would
+	for i := 0; i < 2; i++ {
+		var m ReceivedMessage
+		if err := json.Unmarshal(buf, &m); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+
+		h, ok := m.Actions[0].(*HttpAction[string])
+		if !ok || h.Body != "hi" {
+			t.Fatalf("round trip %d: unexpected action %#v", i, m.Actions[0])
+		}
+
+		if buf, err = json.Marshal(&Message{Topic: m.Topic, Actions: m.Actions}); err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+	}
+}
+
+func TestHTTPActionPlainStringBody(t *testing.T) {
+	var h HttpAction[string]
+	if err := json.Unmarshal([]byte(`{"action":"http","label":"Send","body":"plain text"}`), &h); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if h.Body != "plain text" {
+		t.Errorf("unexpected body: got = %q, want = %q", h.Body, "plain text")
+	}
+}
@@ -0,0 +1,69 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+)
+
+// Permission is the access granted to a topic
+type Permission string
+
+const (
+	ReadWrite Permission = "read-write"
+	ReadOnly  Permission = "read-only"
+	WriteOnly Permission = "write-only"
+	DenyAll   Permission = "deny-all"
+)
+
+// Reservation is a topic reserved by the authenticated user. Everyone is
+// the access granted to all other users, including anonymous ones
+type Reservation struct {
+	Topic    string     `json:"topic" validate:"required"`
+	Everyone Permission `json:"everyone" validate:"oneof=read-write read-only write-only deny-all"`
+}
+
+// ReserveTopic reserves a topic for the authenticated user, granting
+// everyone else the given access to it
+func (c *Client) ReserveTopic(ctx context.Context, topic string, everyone Permission) error {
+	return c.putReservation(ctx, &Reservation{Topic: topic, Everyone: everyone})
+}
+
+// UpdateReservation changes the access everyone else has to a reserved topic
+func (c *Client) UpdateReservation(ctx context.Context, topic string, everyone Permission) error {
+	return c.putReservation(ctx, &Reservation{Topic: topic, Everyone: everyone})
+}
+
+// Reservations returns the topics reserved by the authenticated user
+func (c *Client) Reservations(ctx context.Context) ([]Reservation, error) {
+	account, err := c.Account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Reservations, nil
+}
+
+// DeleteReservation releases a reserved topic, optionally deleting the
+// messages and attachments cached for it
+func (c *Client) DeleteReservation(ctx context.Context, topic string, deleteMessages bool) error {
+	if err := c.validator.Var(topic, "required"); err != nil {
+		return err
+	}
+
+	var headers http.Header
+	if deleteMessages {
+		headers = http.Header{"X-Delete-Messages": {"true"}}
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, c.endpoint("v1", "account", "reservation", topic), nil, nil, headers)
+}
+
+// putReservation creates or updates a reservation, which the server
+// handles with the same endpoint
+func (c *Client) putReservation(ctx context.Context, r *Reservation) error {
+	if err := c.validator.Struct(r); err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPost, c.endpoint("v1", "account", "reservation"), r, nil, nil)
+}
@@ -0,0 +1,120 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestReservationRequests(t *testing.T) {
+	testCases := []struct {
+		name        string
+		call        func(c *Client) error
+		expected    recordedRequest
+		expectedErr bool
+	}{
+		{
+			name: "Reserve",
+			call: func(c *Client) error {
+				return c.ReserveTopic(context.Background(), "project-x", DenyAll)
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/reservation",
+				body:   `{"topic":"project-x","everyone":"deny-all"}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Update",
+			call: func(c *Client) error {
+				return c.UpdateReservation(context.Background(), "project-x", ReadOnly)
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/reservation",
+				body:   `{"topic":"project-x","everyone":"read-only"}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Delete",
+			call: func(c *Client) error {
+				return c.DeleteReservation(context.Background(), "project-x", false)
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/account/reservation/project-x",
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Delete With Messages",
+			call: func(c *Client) error {
+				return c.DeleteReservation(context.Background(), "project-x", true)
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/account/reservation/project-x",
+				auth:   "Bearer tk_client",
+				delete: "true",
+			},
+		},
+		{
+			name: "Invalid Permission",
+			call: func(c *Client) error {
+				return c.ReserveTopic(context.Background(), "project-x", "everything")
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Missing Topic",
+			call: func(c *Client) error {
+				return c.ReserveTopic(context.Background(), "", ReadWrite)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, rec := newRecordingServer(t, http.StatusOK, `{"success":true}`)
+
+			c, err := New(WithHost(srv.URL), WithToken("tk_client"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := tc.call(c); (err != nil) != tc.expectedErr {
+				t.Fatalf("Unexpected error = %v, expectedErr %v", err, tc.expectedErr)
+			}
+
+			if *rec != tc.expected {
+				t.Errorf("Expected request %+v, got %+v", tc.expected, *rec)
+			}
+		})
+	}
+}
+
+func TestReservations(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusOK, `{"username":"phil","reservations":[{"topic":"project-x","everyone":"deny-all"},{"topic":"status","everyone":"read-only"}]}`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	actual, err := c.Reservations(context.Background())
+	if err != nil {
+		t.Fatalf("Reservations() error = %v", err)
+	}
+
+	expected := []Reservation{
+		{Topic: "project-x", Everyone: DenyAll},
+		{Topic: "status", Everyone: ReadOnly},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,85 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how requests that failed with a transient error
+// are retried. Connection errors, 5xx responses and 429 responses are
+// retried; requests whose body cannot be replayed are never retried
+type RetryPolicy struct {
+	MaxRetries int     // Maximum number of retries after the first attempt
+	Backoff    Backoff // Delay between attempts unless the server sends Retry-After
+}
+
+// DefaultRetryPolicy retries up to three times with the default backoff
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	Backoff:    DefaultBackoff,
+}
+
+// retryDelay returns how long to wait before retrying a request that failed
+// with the given error, and whether it should be retried at all
+func (p *RetryPolicy) retryDelay(req *http.Request, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	if !isTransient(err) {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+
+	return p.Backoff.Delay(attempt), true
+}
+
+// isTransient reports whether a request that failed with the given error may
+// succeed later: 5xx responses, 429 responses and transport errors returned
+// by the HTTP client. Other errors, such as a response that cannot be
+// decoded after a successful status, are permanent
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPCode == http.StatusTooManyRequests || apiErr.HTTPCode >= 500
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either in
+// seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
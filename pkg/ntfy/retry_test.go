@@ -0,0 +1,181 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	Backoff:    Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1},
+}
+
+func TestRetryPolicyPublish(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int32
+		wantErr      error
+	}{
+		{
+			name:         "Success after server errors",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Rate limited",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 2,
+		},
+		{
+			name:         "Client errors are not retried",
+			statuses:     []int{http.StatusForbidden, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      ErrForbidden,
+		},
+		{
+			name:         "Retries exhausted",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 3,
+			wantErr:      ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				if body, _ := io.ReadAll(r.Body); !strings.Contains(string(body), `"topic":"t1"`) {
+					t.Errorf("attempt %d: unexpected body %q", n, body)
+				}
+
+				w.WriteHeader(tt.statuses[n-1])
+				w.Write([]byte(`{"id":"m1"}`))
+			}))
+			defer srv.Close()
+
+			c, err := New(WithHost(srv.URL), WithRetryPolicy(testRetryPolicy))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = c.Publish(context.Background(), &PublishOpts{Message: &Message{Topic: "t1"}})
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Publish() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("unexpected number of attempts: got = %d, want = %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyNonReplayableBody(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL), WithRetryPolicy(testRetryPolicy))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Wrapping the reader hides its type, so the request body cannot be replayed
+	body := io.MultiReader(strings.NewReader("file contents"))
+	if _, err := c.PublishFile(context.Background(), "t1", body, "file.txt", nil); err == nil {
+		t.Error("expected error from PublishFile")
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("unexpected number of attempts: got = %d, want = 1", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := &RetryPolicy{MaxRetries: 1, Backoff: Backoff{Initial: time.Second, Multiplier: 2}}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	tests := []struct {
+		name      string
+		policy    *RetryPolicy
+		err       error
+		attempt   int
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{name: "No policy", err: errors.New("reset"), wantRetry: false},
+		{name: "Connection error", policy: p, err: &url.Error{Op: "Get", URL: "/", Err: errors.New("reset")}, wantDelay: time.Second, wantRetry: true},
+		{name: "Decode error", policy: p, err: io.ErrUnexpectedEOF, wantRetry: false},
+		{name: "Cancelled", policy: p, err: context.Canceled, wantRetry: false},
+		{name: "Retry-After", policy: p, err: &APIError{HTTPCode: 429, RetryAfter: 5 * time.Second}, wantDelay: 5 * time.Second, wantRetry: true},
+		{name: "Server error", policy: p, err: &APIError{HTTPCode: 500}, wantDelay: time.Second, wantRetry: true},
+		{name: "Client error", policy: p, err: &APIError{HTTPCode: 400}, wantRetry: false},
+		{name: "Exhausted", policy: p, err: &APIError{HTTPCode: 500}, attempt: 1, wantRetry: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := tt.policy.retryDelay(req, tt.err, tt.attempt)
+			if delay != tt.wantDelay || retry != tt.wantRetry {
+				t.Errorf("retryDelay() = (%v, %v), want (%v, %v)", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestRetryDelayUnsetBackoff(t *testing.T) {
+	c, err := New(WithRetryPolicy(RetryPolicy{MaxRetries: 3}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	errs := []error{&APIError{HTTPCode: http.StatusBadGateway}, &url.Error{Op: "Get", URL: "/", Err: errors.New("refused")}}
+	for _, err := range errs {
+		for attempt := 0; attempt < 3; attempt++ {
+			delay, retry := c.retryPolicy.retryDelay(req, err, attempt)
+			if !retry {
+				t.Fatalf("retryDelay(%v, %d) did not retry", err, attempt)
+			}
+
+			// The default backoff doubles from one second with 50% jitter
+			upper := DefaultBackoff.Initial << attempt
+			if delay < upper/2 || delay > upper {
+				t.Errorf("retryDelay(%v, %d) = %v, want between %v and %v", err, attempt, delay, upper/2, upper)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("120"); got != 2*time.Minute {
+		t.Errorf("parseRetryAfter(seconds) = %v, want %v", got, 2*time.Minute)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(date) = %v, want about an hour", got)
+	}
+
+	for _, value := range []string{"", "soon", "-1"} {
+		if got := parseRetryAfter(value); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0", value, got)
+		}
+	}
+}
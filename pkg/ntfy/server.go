@@ -0,0 +1,66 @@
+package ntfy
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Health is the health of the server
+	Health struct {
+		Healthy bool `json:"healthy"`
+	}
+
+	// Stats are the message statistics of the server
+	Stats struct {
+		Messages     int64   `json:"messages"`      // Number of messages published since the server started
+		MessagesRate float64 `json:"messages_rate"` // Average number of messages per second
+	}
+
+	// ServerConfig describes the features enabled on the server. Attachment
+	// and other limits depend on the user and are reported by Account
+	ServerConfig struct {
+		BaseURL            string   `json:"base_url"`
+		AppRoot            string   `json:"app_root"`
+		EnableLogin        bool     `json:"enable_login"`
+		EnableSignup       bool     `json:"enable_signup"`
+		EnablePayments     bool     `json:"enable_payments"`
+		EnableCalls        bool     `json:"enable_calls"`
+		EnableEmails       bool     `json:"enable_emails"`
+		EnableReservations bool     `json:"enable_reservations"`
+		EnableWebPush      bool     `json:"enable_web_push"`
+		BillingContact     string   `json:"billing_contact,omitempty"`
+		WebPushPublicKey   string   `json:"web_push_public_key,omitempty"`
+		DisallowedTopics   []string `json:"disallowed_topics,omitempty"`
+	}
+)
+
+// Health returns the health of the server. It fails if the server cannot be reached
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	var health Health
+	if err := c.doJSON(ctx, http.MethodGet, c.endpoint("v1", "health"), nil, &health, nil); err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// Stats returns the message statistics of the server
+func (c *Client) Stats(ctx context.Context) (*Stats, error) {
+	var stats Stats
+	if err := c.doJSON(ctx, http.MethodGet, c.endpoint("v1", "stats"), nil, &stats, nil); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// ServerConfig returns the features enabled on the server
+func (c *Client) ServerConfig(ctx context.Context) (*ServerConfig, error) {
+	var config ServerConfig
+	if err := c.doJSON(ctx, http.MethodGet, c.endpoint("v1", "config"), nil, &config, nil); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,94 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestServerInfo(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		call         func(c *Client) (any, error)
+		expectedPath string
+		expected     any
+	}{
+		{
+			name: "Health",
+			body: `{"healthy":true}`,
+			call: func(c *Client) (any, error) {
+				return c.Health(context.Background())
+			},
+			expectedPath: "/v1/health",
+			expected:     &Health{Healthy: true},
+		},
+		{
+			name: "Stats",
+			body: `{"messages":1234,"messages_rate":2.5}`,
+			call: func(c *Client) (any, error) {
+				return c.Stats(context.Background())
+			},
+			expectedPath: "/v1/stats",
+			expected:     &Stats{Messages: 1234, MessagesRate: 2.5},
+		},
+		{
+			name: "Config",
+			body: `{"base_url":"https://ntfy.example.com","app_root":"/app","enable_login":true,"enable_signup":false,` +
+				`"enable_reservations":true,"enable_emails":true,"disallowed_topics":["docs","static"]}`,
+			call: func(c *Client) (any, error) {
+				return c.ServerConfig(context.Background())
+			},
+			expectedPath: "/v1/config",
+			expected: &ServerConfig{
+				BaseURL:            "https://ntfy.example.com",
+				AppRoot:            "/app",
+				EnableLogin:        true,
+				EnableReservations: true,
+				EnableEmails:       true,
+				DisallowedTopics:   []string{"docs", "static"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, rec := newRecordingServer(t, http.StatusOK, tc.body)
+
+			c, err := New(WithHost(srv.URL))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			actual, err := tc.call(c)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if rec.method != http.MethodGet || rec.path != tc.expectedPath {
+				t.Errorf("unexpected request: got = %s %s, want = GET %s", rec.method, rec.path, tc.expectedPath)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHealthUnavailable(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusServiceUnavailable, `{"code":50301,"http":503,"error":"unavailable"}`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = c.Health(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected *APIError with status 503, got %v", err)
+	}
+}
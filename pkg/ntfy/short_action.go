@@ -0,0 +1,333 @@
+package ntfy
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAction is returned when an action in short form cannot be parsed
+var ErrInvalidAction = errors.New("invalid action")
+
+// actionField is a single comma-separated field of an action in short form
+type actionField struct {
+	key   string // Empty for positional fields
+	value string
+}
+
+// FormatActions encodes the given action buttons in ntfy's short form,
+// e.g. view, Open portal, https://example.com, clear=true; http, ...
+func FormatActions(actions []ActionButton) (string, error) {
+	parts := make([]string, len(actions))
+	for i, a := range actions {
+		m, ok := a.(encoding.TextMarshaler)
+		if !ok {
+			return "", fmt.Errorf("%w: %T has no short form", ErrInvalidAction, a)
+		}
+
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		parts[i] = string(b)
+	}
+
+	return strings.Join(parts, "; "), nil
+}
+
+// ParseActions decodes action buttons from ntfy's short form. Actions are
+// separated by semicolons and their fields by commas. The action type, label
+// and URL may be given by position or as key=value pairs; other fields are
+// always key=value pairs. Values containing separators can be enclosed in
+// single or double quotes, and a quote character is escaped with a backslash
+// inside a value enclosed in it; quotes within a value are kept as is.
+// HTTP actions are decoded as *HttpAction[string]
+func ParseActions(s string) ([]ActionButton, error) {
+	raw, err := splitActions(s)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]ActionButton, 0, len(raw))
+	for _, fields := range raw {
+		a, err := parseAction(fields)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, nil
+}
+
+// splitActions tokenizes the short form into the fields of each action
+func splitActions(s string) ([][]actionField, error) {
+	var (
+		actions [][]actionField
+		fields  []actionField
+		buf     strings.Builder
+		key     string
+		hasKey  bool
+		started bool // Whether the value has started, i.e. leading spaces were skipped
+		trail   int  // Length of the value without trailing unquoted spaces
+		quote   rune
+	)
+
+	endField := func() {
+		value := buf.String()[:trail]
+		if hasKey || value != "" || started {
+			fields = append(fields, actionField{key: key, value: value})
+		}
+		buf.Reset()
+		key, hasKey, started, trail = "", false, false, 0
+	}
+
+	endAction := func() {
+		endField()
+		if len(fields) > 0 {
+			actions = append(actions, fields)
+		}
+		fields = nil
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if quote != 0 {
+			switch {
+			case r == '\\' && i+1 < len(runes) && runes[i+1] == quote:
+				i++
+				buf.WriteRune(quote)
+			case r == quote:
+				quote = 0
+			default:
+				buf.WriteRune(r)
+			}
+			trail = buf.Len()
+			continue
+		}
+
+		switch {
+		case r == ';':
+			endAction()
+		case r == ',':
+			endField()
+		case (r == '"' || r == '\'') && !started:
+			quote = r
+			started = true
+		case r == '=' && !hasKey && isActionKey(strings.TrimSpace(buf.String())):
+			key, hasKey = strings.TrimSpace(buf.String()), true
+			buf.Reset()
+			started, trail = false, 0
+		case r == ' ' || r == '\t':
+			if started {
+				buf.WriteRune(r)
+			}
+		default:
+			started = true
+			buf.WriteRune(r)
+			trail = buf.Len()
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidAction)
+	}
+	endAction()
+
+	return actions, nil
+}
+
+// isActionKey reports whether k is a key known by the short form
+func isActionKey(k string) bool {
+	switch k {
+	case "action", "label", "url", "method", "body", "intent", "clear":
+		return true
+	}
+
+	for _, prefix := range []string{"headers.", "extras."} {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAction builds an action button from its fields
+func parseAction(fields []actionField) (ActionButton, error) {
+	var (
+		kind, label, link string
+		keyed             []actionField
+		position          int
+	)
+
+	for _, f := range fields {
+		switch {
+		case f.key == "" && position == 0, f.key == "action":
+			kind = f.value
+		case f.key == "" && position == 1, f.key == "label":
+			label = f.value
+		case f.key == "" && position == 2, f.key == "url":
+			link = f.value
+		case f.key == "":
+			return nil, fmt.Errorf("%w: unexpected value %q", ErrInvalidAction, f.value)
+		default:
+			keyed = append(keyed, f)
+		}
+
+		if f.key == "" {
+			position++
+		}
+	}
+
+	if label == "" {
+		return nil, fmt.Errorf("%w: missing label", ErrInvalidAction)
+	}
+
+	u, err := parseOptionalURL(link)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAction, err)
+	}
+
+	var (
+		a     ActionButton
+		clear *bool
+		set   func(f actionField) bool
+	)
+
+	switch kind {
+	case "view":
+		v := &ViewAction{Label: label, Link: u}
+		a, clear = v, &v.Clear
+		set = func(actionField) bool { return false }
+	case "http":
+		h := &HttpAction[string]{Label: label, URL: u}
+		a, clear = h, &h.Clear
+		set = func(f actionField) bool {
+			switch {
+			case f.key == "method":
+				h.Method = f.value
+			case f.key == "body":
+				h.Body = f.value
+			case strings.HasPrefix(f.key, "headers."):
+				if h.Headers == nil {
+					h.Headers = map[string]string{}
+				}
+				h.Headers[strings.TrimPrefix(f.key, "headers.")] = f.value
+			default:
+				return false
+			}
+			return true
+		}
+	case "broadcast":
+		if u != nil {
+			return nil, fmt.Errorf("%w: unexpected url for broadcast action", ErrInvalidAction)
+		}
+		b := &BroadcastAction{Label: label}
+		a, clear = b, &b.Clear
+		set = func(f actionField) bool {
+			switch {
+			case f.key == "intent":
+				b.Intent = f.value
+			case strings.HasPrefix(f.key, "extras."):
+				if b.Extras == nil {
+					b.Extras = map[string]string{}
+				}
+				b.Extras[strings.TrimPrefix(f.key, "extras.")] = f.value
+			default:
+				return false
+			}
+			return true
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown action type %q", ErrInvalidAction, kind)
+	}
+
+	for _, f := range keyed {
+		if f.key == "clear" {
+			v, err := strconv.ParseBool(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid clear value %q", ErrInvalidAction, f.value)
+			}
+			*clear = v
+			continue
+		}
+
+		if !set(f) {
+			return nil, fmt.Errorf("%w: unexpected key %q for %s action", ErrInvalidAction, f.key, kind)
+		}
+	}
+
+	return a, nil
+}
+
+// formatAction joins the fields of an action in short form
+func formatAction(kind, label string, link *url.URL, keyed []actionField, clear bool) ([]byte, error) {
+	value, err := quoteActionValue(label)
+	if err != nil {
+		return nil, err
+	}
+	parts := []string{kind, value}
+
+	if link != nil {
+		if value, err = quoteActionValue(link.String()); err != nil {
+			return nil, err
+		}
+		parts = append(parts, value)
+	}
+
+	for _, f := range keyed {
+		if value, err = quoteActionValue(f.value); err != nil {
+			return nil, err
+		}
+		parts = append(parts, f.key+"="+value)
+	}
+
+	if clear {
+		parts = append(parts, "clear=true")
+	}
+
+	return []byte(strings.Join(parts, ", ")), nil
+}
+
+// mapFields returns the entries of m as keyed fields with the given key
+// prefix, sorted by key
+func mapFields(prefix string, m map[string]string) []actionField {
+	fields := make([]actionField, 0, len(m))
+	for key, value := range m {
+		fields = append(fields, actionField{key: prefix + key, value: value})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+
+	return fields
+}
+
+// quoteActionValue quotes a value of the short form if it contains
+// separators or quotes, or would otherwise lose surrounding whitespace.
+// Values containing both kinds of quotes escape the double quotes. The
+// short form has no escape for backslashes, so a quoted value cannot end
+// with one: it would escape the closing quote
+func quoteActionValue(s string) (string, error) {
+	if s != "" && s == strings.TrimSpace(s) && !strings.ContainsAny(s, `,;"'=`) {
+		return s, nil
+	}
+
+	if strings.HasSuffix(s, `\`) {
+		return "", fmt.Errorf("%w: value %q ends with a backslash and cannot be quoted", ErrInvalidAction, s)
+	}
+
+	switch {
+	case !strings.Contains(s, `"`):
+		return `"` + s + `"`, nil
+	case !strings.Contains(s, `'`):
+		return `'` + s + `'`, nil
+	default:
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`, nil
+	}
+}
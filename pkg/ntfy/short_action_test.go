@@ -0,0 +1,170 @@
+package ntfy
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestFormatActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []ActionButton
+		want    string
+	}{
+		{
+			name: "View action",
+			actions: []ActionButton{&ViewAction{
+				Label: "Open portal",
+				Link:  &url.URL{Scheme: "https", Host: "x"},
+				Clear: true,
+			}},
+			want: "view, Open portal, https://x, clear=true",
+		},
+		{
+			name: "HTTP action",
+			actions: []ActionButton{&HttpAction[string]{
+				Label:   "Close door",
+				URL:     &url.URL{Scheme: "https", Host: "api", Path: "/door"},
+				Method:  "PUT",
+				Headers: map[string]string{"Authorization": "Bearer x", "Accept": "*/*"},
+				Body:    `{"state":"closed"}`,
+			}},
+			want: `http, Close door, https://api/door, method=PUT, headers.Accept=*/*, headers.Authorization=Bearer x, body='{"state":"closed"}'`,
+		},
+		{
+			name: "Multiple actions with quoting",
+			actions: []ActionButton{
+				&ViewAction{Label: "Docs, FAQ", Link: &url.URL{Scheme: "https", Host: "x", RawQuery: "a=b"}},
+				&BroadcastAction{Label: `It's "on"`, Extras: map[string]string{"cmd": "a;b"}},
+			},
+			want: `view, "Docs, FAQ", "https://x?a=b"; broadcast, "It's \"on\"", extras.cmd="a;b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatActions(tt.actions)
+			if err != nil {
+				t.Fatalf("FormatActions() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("FormatActions() =\n %s\nwant\n %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    []ActionButton
+		wantErr bool
+	}{
+		{
+			name: "Positional view and keyed http",
+			arg:  "view, Open portal, https://x, clear=true; http, Close door, https://api/door, method=PUT, headers.Authorization=Bearer x",
+			want: []ActionButton{
+				&ViewAction{Label: "Open portal", Link: &url.URL{Scheme: "https", Host: "x"}, Clear: true},
+				&HttpAction[string]{
+					Label:   "Close door",
+					URL:     &url.URL{Scheme: "https", Host: "api", Path: "/door"},
+					Method:  "PUT",
+					Headers: map[string]string{"Authorization": "Bearer x"},
+				},
+			},
+		},
+		{
+			name: "Keyed fields",
+			arg:  "action=http, label=Reboot, url=https://x/reboot?force=1, body={\"a\":1}, clear=1",
+			want: []ActionButton{&HttpAction[string]{
+				Label: "Reboot",
+				URL:   &url.URL{Scheme: "https", Host: "x", Path: "/reboot", RawQuery: "force=1"},
+				Body:  `{"a":1}`,
+				Clear: true,
+			}},
+		},
+		{
+			name: "Broadcast",
+			arg:  "broadcast, Take picture, intent=com.example.PICTURE, extras.cmd=pic, extras.camera=front",
+			want: []ActionButton{&BroadcastAction{
+				Label:  "Take picture",
+				Intent: "com.example.PICTURE",
+				Extras: map[string]string{"cmd": "pic", "camera": "front"},
+			}},
+		},
+		{
+			name: "Quoting and escaping",
+			arg:  `view, "Docs, FAQ; more", 'https://x?q=a,b'; broadcast, "It's \"on\"", extras.cmd=' padded '`,
+			want: []ActionButton{
+				&ViewAction{Label: "Docs, FAQ; more", Link: &url.URL{Scheme: "https", Host: "x", RawQuery: "q=a,b"}},
+				&BroadcastAction{Label: `It's "on"`, Extras: map[string]string{"cmd": " padded "}},
+			},
+		},
+		{
+			name: "Trailing separators",
+			arg:  " view , Open , https://x ; ",
+			want: []ActionButton{&ViewAction{Label: "Open", Link: &url.URL{Scheme: "https", Host: "x"}}},
+		},
+		{name: "Unknown type", arg: "launch, Go", wantErr: true},
+		{name: "Missing label", arg: "view", wantErr: true},
+		{name: "Unterminated quote", arg: `view, "Open`, wantErr: true},
+		{name: "Unexpected key", arg: "view, Open, https://x, method=GET", wantErr: true},
+		{name: "Too many values", arg: "view, Open, https://x, extra", wantErr: true},
+		{name: "Invalid clear", arg: "view, Open, clear=maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseActions(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseActions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if !errors.Is(err, ErrInvalidAction) {
+					t.Errorf("ParseActions() error = %v, want ErrInvalidAction", err)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseActions() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionsRoundTrip(t *testing.T) {
+	actions := []ActionButton{
+		&ViewAction{Label: "a, 'b'", Link: &url.URL{Scheme: "https", Host: "x", RawQuery: "a=b;c"}, Clear: true},
+		&HttpAction[string]{Label: `"both" 'quotes'`, Method: "POST", Headers: map[string]string{"X-A": "1=2"}, Body: "x, y"},
+		&BroadcastAction{Label: "label=value", Intent: "i", Extras: map[string]string{"k": ""}},
+		&BroadcastAction{Label: `C:\dir\`, Extras: map[string]string{"k": `a\"b'c`, "path": `C:\a, b`, "q": `it's \'x\'`}},
+	}
+
+	s, err := FormatActions(actions)
+	if err != nil {
+		t.Fatalf("FormatActions() error = %v", err)
+	}
+
+	got, err := ParseActions(s)
+	if err != nil {
+		t.Fatalf("ParseActions(%q) error = %v", s, err)
+	}
+
+	if !reflect.DeepEqual(got, actions) {
+		t.Errorf("round trip of %q = %#v, want %#v", s, got, actions)
+	}
+}
+
+func TestFormatActionsTrailingBackslash(t *testing.T) {
+	actions := []ActionButton{&BroadcastAction{Label: "Run", Extras: map[string]string{"k": `a"b'c\`}}}
+
+	if _, err := FormatActions(actions); !errors.Is(err, ErrInvalidAction) {
+		t.Errorf("FormatActions() error = %v, want %v", err, ErrInvalidAction)
+	}
+}
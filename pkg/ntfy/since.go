@@ -0,0 +1,42 @@
+package ntfy
+
+import (
+	"strconv"
+	"time"
+)
+
+// Since selects the cached messages returned by the server. The zero value
+// leaves the choice to the server
+type Since struct {
+	value string
+}
+
+var (
+	SinceAll    = Since{value: "all"}    // All cached messages
+	SinceLatest = Since{value: "latest"} // Only the most recent message
+)
+
+// SinceDuration selects messages published within the given duration
+func SinceDuration(d time.Duration) Since {
+	return Since{value: d.String()}
+}
+
+// SinceTime selects messages published after the given time
+func SinceTime(t time.Time) Since {
+	return Since{value: strconv.FormatInt(t.Unix(), 10)}
+}
+
+// SinceID selects messages published after the message with the given ID
+func SinceID(id string) Since {
+	return Since{value: id}
+}
+
+// IsZero reports whether no cursor is set
+func (s Since) IsZero() bool {
+	return s.value == ""
+}
+
+// String returns the value of the since query parameter
+func (s Since) String() string {
+	return s.value
+}
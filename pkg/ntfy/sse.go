@@ -0,0 +1,211 @@
+package ntfy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSSERetry is the delay before reconnecting to an SSE stream unless
+// the server requested a different one
+const defaultSSERetry = time.Second
+
+type (
+	// sseEvent is a single frame dispatched from a Server-Sent Events stream
+	sseEvent struct {
+		ID    string
+		Event string
+		Data  string
+	}
+
+	// sseReader parses a Server-Sent Events stream into events
+	sseReader struct {
+		r      *bufio.Reader
+		lastID string
+		retry  time.Duration
+	}
+)
+
+// SubscribeSSE opens a Server-Sent Events stream to the given topics and
+// delivers every event sent by the server on the returned subscription.
+// When the connection drops, it reconnects and resumes after the last
+// received event so no messages are lost, retrying with a backoff while the
+// server cannot be reached
+func (c *Client) SubscribeSSE(ctx context.Context, opts *SubscribeOpts) (*Subscription, error) {
+	return c.subscribeSSE(ctx, opts, true)
+}
+
+// subscribeSSE opens a Server-Sent Events stream. Without reconnect, the
+// subscription ends with ErrSubscriptionClosed when the connection drops,
+// leaving it to the caller to reconnect, as the Subscriber does
+func (c *Client) subscribeSSE(ctx context.Context, opts *SubscribeOpts, reconnect bool) (*Subscription, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	connect := func(lastID string) (io.ReadCloser, error) {
+		u := c.subscribeURL(opts, "sse")
+		if lastID != "" {
+			// Resume after the last received event instead of replaying
+			// everything the original since parameter selected
+			resume := *opts
+			resume.Since = SinceID(lastID)
+			u = c.subscribeURL(&resume, "sse")
+		}
+
+		req, err := c.newRequest(ctx, http.MethodGet, u, nil, opts.Headers)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "text/event-stream")
+		if lastID != "" {
+			req.Header.Set("Last-Event-ID", lastID)
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Body, nil
+	}
+
+	body, err := connect("")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newSubscription(ctx, cancel, func(emit func(*ReceivedMessage) bool) error {
+		sr := &sseReader{retry: defaultSSERetry}
+		for {
+			err := sr.stream(body, emit)
+			body.Close()
+			if err != nil || ctx.Err() != nil {
+				return err
+			}
+
+			if !reconnect {
+				return ErrSubscriptionClosed
+			}
+
+			if body, err = sr.reconnect(ctx, connect); err != nil {
+				return err
+			}
+		}
+	}), nil
+}
+
+// reconnect waits for the retry delay requested by the server and connects
+// again, backing off from that delay while the server cannot be reached.
+// It gives up on errors that are not transient
+func (sr *sseReader) reconnect(ctx context.Context, connect func(lastID string) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	b := DefaultBackoff
+	if sr.retry > 0 {
+		b.Initial = sr.retry
+		b.Max = max(b.Max, sr.retry)
+	}
+
+	for attempt := 0; ; attempt++ {
+		delay := sr.retry
+		if attempt > 0 {
+			delay = b.Delay(attempt)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		body, err := connect(sr.lastID)
+		if err == nil {
+			return body, nil
+		}
+
+		if ctx.Err() != nil || !isTransient(err) {
+			return nil, err
+		}
+	}
+}
+
+// stream decodes events from the given body until it ends or emit reports
+// that no more messages are wanted. Read errors are treated as a dropped
+// connection and return nil so that the stream is resumed
+func (sr *sseReader) stream(body io.Reader, emit func(*ReceivedMessage) bool) error {
+	sr.r = bufio.NewReader(body)
+	for {
+		ev, err := sr.next()
+		if err != nil {
+			return nil
+		}
+
+		var m ReceivedMessage
+		if err := json.Unmarshal([]byte(ev.Data), &m); err != nil {
+			return err
+		}
+
+		if m.Event == "" {
+			m.Event = Event(ev.Event)
+		}
+		if m.ID == "" {
+			m.ID = ev.ID
+		}
+
+		if !emit(&m) {
+			return nil
+		}
+	}
+}
+
+// next reads lines until a complete event with data has been dispatched
+func (sr *sseReader) next() (*sseEvent, error) {
+	ev := &sseEvent{Event: string(MessageEvent)}
+	var data []string
+	for {
+		line, err := sr.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if data == nil {
+				ev = &sseEvent{Event: string(MessageEvent)}
+				continue
+			}
+
+			ev.ID = sr.lastID
+			ev.Data = strings.Join(data, "\n")
+			return ev, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				sr.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				sr.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package ntfy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSSEReaderNext(t *testing.T) {
+	stream := ": comment\r\n" +
+		"retry: 50\n" +
+		"id: a1\n" +
+		"event: open\n" +
+		"data: {\"id\":\"a1\",\"event\":\"open\"}\n" +
+		"\n" +
+		"\n" +
+		"data: line1\n" +
+		"data:line2\n" +
+		"\n"
+
+	sr := &sseReader{r: bufio.NewReader(strings.NewReader(stream)), retry: defaultSSERetry}
+
+	var got []sseEvent
+	for {
+		ev, err := sr.next()
+		if err != nil {
+			break
+		}
+		got = append(got, *ev)
+	}
+
+	want := []sseEvent{
+		{ID: "a1", Event: "open", Data: `{"id":"a1","event":"open"}`},
+		{ID: "a1", Event: "message", Data: "line1\nline2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events:\n got  = %+v\n want = %+v", got, want)
+	}
+
+	if sr.retry != 50*time.Millisecond {
+		t.Errorf("unexpected retry: got = %v, want = %v", sr.retry, 50*time.Millisecond)
+	}
+}
+
+func TestSubscribeSSEResume(t *testing.T) {
+	var (
+		conns  atomic.Int32
+		lastID atomic.Value
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/t1/sse" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch conns.Add(1) {
+		case 1:
+			fmt.Fprint(w, "retry: 10\n")
+			fmt.Fprint(w, "id: o1\nevent: open\ndata: {\"id\":\"o1\",\"time\":1,\"event\":\"open\",\"topic\":\"t1\"}\n\n")
+			fmt.Fprint(w, "id: m1\ndata: {\"id\":\"m1\",\"time\":2,\"event\":\"message\",\"topic\":\"t1\",\"message\":\"one\"}\n\n")
+			w.(http.Flusher).Flush()
+		default:
+			lastID.Store(r.Header.Get("Last-Event-ID"))
+			fmt.Fprint(w, "id: m2\ndata: {\"id\":\"m2\",\"time\":3,\"event\":\"message\",\"topic\":\"t1\",\"message\":\"two\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := c.SubscribeSSE(ctx, &SubscribeOpts{Topics: []string{"t1"}})
+	if err != nil {
+		t.Fatalf("SubscribeSSE() error = %v", err)
+	}
+
+	var got []string
+	for m := range sub.Messages() {
+		got = append(got, m.ID)
+		if len(got) == 3 {
+			sub.Close()
+		}
+	}
+
+	if want := []string{"o1", "m1", "m2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected message IDs: got = %v, want = %v", got, want)
+	}
+
+	if id, _ := lastID.Load().(string); id != "m1" {
+		t.Errorf("unexpected Last-Event-ID: got = %q, want = %q", id, "m1")
+	}
+
+	if err := sub.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeSSEReconnectRetries(t *testing.T) {
+	var (
+		conns  atomic.Int32
+		since  atomic.Value
+		lastID atomic.Value
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch conns.Add(1) {
+		case 1:
+			if got := r.URL.Query().Get("since"); got != "all" {
+				t.Errorf("unexpected since on first connect: got = %q, want = all", got)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "retry: 5\n")
+			fmt.Fprint(w, "id: m1\ndata: {\"id\":\"m1\",\"time\":1,\"event\":\"message\",\"topic\":\"t1\"}\n\n")
+		case 2:
+			// Server restarting
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			since.Store(r.URL.Query().Get("since"))
+			lastID.Store(r.Header.Get("Last-Event-ID"))
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: m2\ndata: {\"id\":\"m2\",\"time\":2,\"event\":\"message\",\"topic\":\"t1\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := c.SubscribeSSE(ctx, &SubscribeOpts{Topics: []string{"t1"}, Since: SinceAll})
+	if err != nil {
+		t.Fatalf("SubscribeSSE() error = %v", err)
+	}
+
+	var got []string
+	for m := range sub.Messages() {
+		got = append(got, m.ID)
+		if len(got) == 2 {
+			sub.Close()
+		}
+	}
+
+	if want := []string{"m1", "m2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected message IDs: got = %v, want = %v", got, want)
+	}
+	if n := conns.Load(); n != 3 {
+		t.Errorf("unexpected number of connections: got = %d, want = 3", n)
+	}
+	if s, _ := since.Load().(string); s != "m1" {
+		t.Errorf("unexpected since on reconnect: got = %q, want = m1", s)
+	}
+	if id, _ := lastID.Load().(string); id != "m1" {
+		t.Errorf("unexpected Last-Event-ID: got = %q, want = m1", id)
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeSSEReconnectUnauthorized(t *testing.T) {
+	var conns atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conns.Add(1) > 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "retry: 1\n")
+		fmt.Fprint(w, "id: m1\ndata: {\"id\":\"m1\",\"time\":1,\"event\":\"message\",\"topic\":\"t1\"}\n\n")
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	sub, err := c.SubscribeSSE(context.Background(), &SubscribeOpts{Topics: []string{"t1"}})
+	if err != nil {
+		t.Fatalf("SubscribeSSE() error = %v", err)
+	}
+
+	for range sub.Messages() {
+	}
+
+	if err := sub.Err(); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected error %v, got %v", ErrUnauthorized, err)
+	}
+}
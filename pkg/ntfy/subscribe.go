@@ -0,0 +1,154 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// SubscribeOpts configures a subscription to one or more topics
+	SubscribeOpts struct {
+		Topics  []string    `validate:"required,min=1,dive,required"`
+		Since   Since       `validate:"-"` // Also deliver cached messages selected by the cursor
+		Filter  *Filter     // Only deliver messages matching the filter
+		Headers http.Header `validate:"-"`
+	}
+
+	// Subscription is a stream of messages received from the ntfy server.
+	// Messages are delivered until the context passed when subscribing is
+	// cancelled, Close is called or the connection is closed
+	Subscription struct {
+		messages chan *ReceivedMessage
+		cancel   context.CancelFunc
+		done     chan struct{}
+		err      error
+	}
+)
+
+// ErrSubscriptionClosed is returned by Subscription.Err when the server closed the stream
+var ErrSubscriptionClosed = errors.New("subscription closed by server")
+
+// Subscribe opens a stream to the JSON endpoint of the given topics and
+// delivers every event sent by the server on the returned subscription
+func (c *Client) Subscribe(ctx context.Context, opts *SubscribeOpts) (*Subscription, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	u := c.subscribeURL(opts, "json")
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil, opts.Headers)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newSubscription(ctx, cancel, func(emit func(*ReceivedMessage) bool) error {
+		defer resp.Body.Close()
+
+		if err := readJSONStream(resp.Body, emit); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ErrSubscriptionClosed
+			}
+			return err
+		}
+
+		return nil
+	}), nil
+}
+
+// subscribeURL returns the URL of the given streaming endpoint for the subscription
+func (c *Client) subscribeURL(opts *SubscribeOpts, endpoint string) *url.URL {
+	u := c.endpoint(strings.Join(opts.Topics, ","), endpoint)
+
+	q := u.Query()
+	if !opts.Since.IsZero() {
+		q.Set("since", opts.Since.String())
+	}
+	opts.Filter.apply(q)
+	u.RawQuery = q.Encode()
+
+	return u
+}
+
+// readJSONStream decodes newline-delimited messages until the stream ends
+// or fn reports that no more messages are wanted
+func readJSONStream(r io.Reader, fn func(*ReceivedMessage) bool) error {
+	dec := json.NewDecoder(r)
+	for {
+		var m ReceivedMessage
+		if err := dec.Decode(&m); err != nil {
+			return err
+		}
+
+		if !fn(&m) {
+			return nil
+		}
+	}
+}
+
+// newSubscription runs the given stream reader in the background until it
+// returns or the context is cancelled
+func newSubscription(ctx context.Context, cancel context.CancelFunc, run func(emit func(*ReceivedMessage) bool) error) *Subscription {
+	s := &Subscription{
+		messages: make(chan *ReceivedMessage),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		defer close(s.messages)
+		defer cancel()
+
+		err := run(func(m *ReceivedMessage) bool {
+			select {
+			case s.messages <- m:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if ctx.Err() == nil {
+			s.err = err
+		}
+	}()
+
+	return s
+}
+
+// Messages returns the channel on which received messages are delivered.
+// The channel is closed when the subscription ends
+func (s *Subscription) Messages() <-chan *ReceivedMessage {
+	return s.messages
+}
+
+// Err returns the error that ended the subscription, if any. It returns nil
+// while the subscription is running or if it was ended by the caller
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close ends the subscription and waits for the underlying connection to be released
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
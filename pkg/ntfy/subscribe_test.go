@@ -0,0 +1,125 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSubscribe(t *testing.T) {
+	lines := []string{
+		`{"id":"a1","time":1700000000,"event":"open","topic":"t1,t2"}`,
+		`{"id":"a2","time":1700000001,"event":"keepalive","topic":"t1,t2"}`,
+		`{"id":"a3","time":1700000002,"expires":1700043202,"event":"message","topic":"t1","message":"hello","title":"Hi","tags":["tag"],"priority":4,"click":"https://example.com"}`,
+	}
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	sub, err := c.Subscribe(context.Background(), &SubscribeOpts{Topics: []string{"t1", "t2"}})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer sub.Close()
+
+	var got []*ReceivedMessage
+	for m := range sub.Messages() {
+		got = append(got, m)
+	}
+
+	if gotPath != "/t1,t2/json" {
+		t.Errorf("unexpected path: got = %s, want = /t1,t2/json", gotPath)
+	}
+
+	want := []*ReceivedMessage{
+		{ID: "a1", Time: 1700000000, Event: OpenEvent, Topic: "t1,t2"},
+		{ID: "a2", Time: 1700000001, Event: KeepaliveEvent, Topic: "t1,t2"},
+		{
+			ID:       "a3",
+			Time:     1700000002,
+			Expires:  1700043202,
+			Event:    MessageEvent,
+			Topic:    "t1",
+			Message:  "hello",
+			Title:    "Hi",
+			Tags:     []string{"tag"},
+			Priority: High,
+			Click:    "https://example.com",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected messages:\n got  = %+v\n want = %+v", got, want)
+	}
+
+	if err := sub.Err(); !errors.Is(err, ErrSubscriptionClosed) {
+		t.Errorf("unexpected error: got = %v, want = %v", err, ErrSubscriptionClosed)
+	}
+}
+
+func TestSubscribeClose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"a1","time":1700000000,"event":"open","topic":"t1"}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	sub, err := c.Subscribe(context.Background(), &SubscribeOpts{Topics: []string{"t1"}})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if m := <-sub.Messages(); m == nil || m.Event != OpenEvent {
+		t.Fatalf("unexpected first message: %+v", m)
+	}
+
+	sub.Close()
+
+	if _, ok := <-sub.Messages(); ok {
+		t.Error("expected messages channel to be closed")
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("unexpected error after Close: %v", err)
+	}
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.Subscribe(context.Background(), &SubscribeOpts{}); err == nil {
+		t.Error("expected validation error for missing topics")
+	}
+
+	if _, err := c.Subscribe(context.Background(), &SubscribeOpts{Topics: []string{"t1"}}); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,167 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Transport is the streaming endpoint used by a Subscriber
+type Transport byte
+
+const (
+	JSONTransport      Transport = iota // Newline-delimited JSON stream
+	SSETransport                        // Server-Sent Events stream
+	WebSocketTransport                  // WebSocket connection
+)
+
+type (
+	// Subscriber is a long-lived subscription that reconnects with
+	// exponential backoff whenever the connection is lost. On reconnect it
+	// resumes after the last received message so that no messages are missed
+	Subscriber struct {
+		client  *Client
+		opts    SubscribeOpts
+		options *SubscriberOptions
+	}
+
+	SubscriberOptions struct {
+		Transport    Transport
+		Backoff      Backoff
+		OnConnect    func()          // Called after a connection is established
+		OnDisconnect func(err error) // Called when an established connection is lost
+		OnError      func(err error) // Called when a connection attempt fails
+	}
+
+	SubscriberOption func(*SubscriberOptions)
+)
+
+func WithTransport(t Transport) SubscriberOption {
+	return func(o *SubscriberOptions) {
+		o.Transport = t
+	}
+}
+
+func WithBackoff(b Backoff) SubscriberOption {
+	return func(o *SubscriberOptions) {
+		o.Backoff = b
+	}
+}
+
+func WithOnConnect(fn func()) SubscriberOption {
+	return func(o *SubscriberOptions) {
+		o.OnConnect = fn
+	}
+}
+
+func WithOnDisconnect(fn func(err error)) SubscriberOption {
+	return func(o *SubscriberOptions) {
+		o.OnDisconnect = fn
+	}
+}
+
+func WithOnError(fn func(err error)) SubscriberOption {
+	return func(o *SubscriberOptions) {
+		o.OnError = fn
+	}
+}
+
+// NewSubscriber creates a subscriber for the given subscription with the given options
+func NewSubscriber(client *Client, opts *SubscribeOpts, options ...SubscriberOption) *Subscriber {
+	o := &SubscriberOptions{
+		Transport: JSONTransport,
+		Backoff:   DefaultBackoff,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	return &Subscriber{
+		client:  client,
+		opts:    *opts,
+		options: o,
+	}
+}
+
+// Run subscribes and calls handler for every message event until the
+// context is cancelled. Transient connection failures are reported through
+// the OnError and OnDisconnect hooks and retried with the backoff. Run
+// returns permanent errors, such as a rejected token, instead of retrying
+func (s *Subscriber) Run(ctx context.Context, handler func(*ReceivedMessage)) error {
+	since := s.opts.Since
+	attempt := 0
+	for {
+		opts := s.opts
+		opts.Since = since
+
+		sub, err := s.subscribe(ctx, &opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !isTransient(err) {
+				return err
+			}
+
+			if s.options.OnError != nil {
+				s.options.OnError(err)
+			}
+		} else {
+			attempt = 0
+			if s.options.OnConnect != nil {
+				s.options.OnConnect()
+			}
+
+			for m := range sub.Messages() {
+				switch m.Event {
+				case OpenEvent:
+					// Resume from the time the connection was established if
+					// no message has been received yet
+					if since.IsZero() {
+						since = SinceTime(time.Unix(m.Time, 0))
+					}
+				case MessageEvent:
+					since = SinceID(m.ID)
+					handler(m)
+				}
+			}
+
+			err = sub.Err()
+			sub.Close()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if s.options.OnDisconnect != nil {
+				s.options.OnDisconnect(err)
+			}
+
+			// Errors ending a stream are dropped connections, unless the
+			// server rejected a reconnect
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && !isTransient(err) {
+				return err
+			}
+		}
+
+		if err := sleep(ctx, s.options.Backoff.Delay(attempt)); err != nil {
+			return err
+		}
+		attempt++
+	}
+}
+
+// subscribe opens a subscription using the configured transport
+func (s *Subscriber) subscribe(ctx context.Context, opts *SubscribeOpts) (*Subscription, error) {
+	switch s.options.Transport {
+	case SSETransport:
+		// The subscriber reconnects itself, so that dropped connections
+		// are reported through its hooks and follow its backoff
+		return s.client.subscribeSSE(ctx, opts, false)
+	case WebSocketTransport:
+		return s.client.SubscribeWebSocket(ctx, opts)
+	default:
+		return s.client.Subscribe(ctx, opts)
+	}
+}
@@ -0,0 +1,212 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscriberResume(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		conns  int
+		sinces []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		conns++
+		n := conns
+		sinces = append(sinces, r.URL.Query().Get("since"))
+		mu.Unlock()
+
+		switch n {
+		case 1:
+			fmt.Fprintln(w, `{"id":"o1","time":100,"event":"open","topic":"t1"}`)
+			fmt.Fprintln(w, `{"id":"m1","time":101,"event":"message","topic":"t1","message":"one"}`)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintln(w, `{"id":"o2","time":102,"event":"open","topic":"t1"}`)
+			fmt.Fprintln(w, `{"id":"m2","time":103,"event":"message","topic":"t1","message":"two"}`)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var connects, disconnects, errs int
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewSubscriber(c, &SubscribeOpts{Topics: []string{"t1"}},
+		WithBackoff(Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1}),
+		WithOnConnect(func() { connects++ }),
+		WithOnDisconnect(func(err error) { disconnects++ }),
+		WithOnError(func(err error) { errs++ }),
+	)
+
+	var got []string
+	err = s.Run(ctx, func(m *ReceivedMessage) {
+		got = append(got, m.ID)
+		if len(got) == 2 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("unexpected Run() error: got = %v, want = %v", err, context.Canceled)
+	}
+
+	if want := []string{"m1", "m2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected messages: got = %v, want = %v", got, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"", "m1", "m1"}; !reflect.DeepEqual(sinces, want) {
+		t.Errorf("unexpected since parameters: got = %v, want = %v", sinces, want)
+	}
+
+	if connects != 2 || disconnects != 1 || errs != 1 {
+		t.Errorf("unexpected hook calls: connects = %d, disconnects = %d, errors = %d", connects, disconnects, errs)
+	}
+}
+
+func TestSubscriberPermanentError(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *SubscribeOpts
+		status  int
+		wantErr error
+	}{
+		{name: "Unauthorized", opts: &SubscribeOpts{Topics: []string{"t1"}}, status: http.StatusUnauthorized, wantErr: ErrUnauthorized},
+		{name: "Forbidden", opts: &SubscribeOpts{Topics: []string{"t1"}}, status: http.StatusForbidden, wantErr: ErrForbidden},
+		{name: "Invalid options", opts: &SubscribeOpts{}, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conns int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conns++
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c, err := New(WithHost(srv.URL), WithToken("tk_wrong"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var errs int
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			s := NewSubscriber(c, tt.opts,
+				WithBackoff(Backoff{Initial: time.Millisecond, Multiplier: 1}),
+				WithOnError(func(err error) { errs++ }),
+			)
+
+			err = s.Run(ctx, func(*ReceivedMessage) {})
+			if err == nil || errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Run() error = %v, want a permanent error", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if conns > 1 || errs != 0 {
+				t.Errorf("permanent error was retried: connections = %d, errors = %d", conns, errs)
+			}
+		})
+	}
+}
+
+func TestSubscriberSSE(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		conns  int
+		sinces []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		conns++
+		n := conns
+		sinces = append(sinces, r.URL.Query().Get("since"))
+		mu.Unlock()
+
+		switch n {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: m1\ndata: {\"id\":\"m1\",\"time\":101,\"event\":\"message\",\"topic\":\"t1\"}\n\n")
+		case 2, 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: m2\ndata: {\"id\":\"m2\",\"time\":102,\"event\":\"message\",\"topic\":\"t1\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var (
+		connects, errs int
+		disconnects    []error
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewSubscriber(c, &SubscribeOpts{Topics: []string{"t1"}},
+		WithTransport(SSETransport),
+		WithBackoff(Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1}),
+		WithOnConnect(func() { connects++ }),
+		WithOnDisconnect(func(err error) { disconnects = append(disconnects, err) }),
+		WithOnError(func(err error) { errs++ }),
+	)
+
+	var got []string
+	err = s.Run(ctx, func(m *ReceivedMessage) {
+		got = append(got, m.ID)
+		if len(got) == 2 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("unexpected Run() error: got = %v, want = %v", err, context.Canceled)
+	}
+
+	if want := []string{"m1", "m2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected messages: got = %v, want = %v", got, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"", "m1", "m1", "m1"}; !reflect.DeepEqual(sinces, want) {
+		t.Errorf("unexpected since parameters: got = %v, want = %v", sinces, want)
+	}
+
+	if connects != 2 || len(disconnects) != 1 || errs != 2 {
+		t.Errorf("unexpected hook calls: connects = %d, disconnects = %d, errors = %d", connects, len(disconnects), errs)
+	}
+	if len(disconnects) == 1 && !errors.Is(disconnects[0], ErrSubscriptionClosed) {
+		t.Errorf("unexpected disconnect error: got = %v, want = %v", disconnects[0], ErrSubscriptionClosed)
+	}
+}
@@ -0,0 +1,271 @@
+package ntfy
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/qubebit/ntfy-go/pkg/emojis"
+)
+
+// Names of the templates rendering the message fields
+const (
+	TitleTemplate    = "title"
+	MessageTemplate  = "message"
+	TagsTemplate     = "tags"
+	PriorityTemplate = "priority"
+	ClickTemplate    = "click"
+)
+
+type (
+	// Template builds messages from text/template strings and arbitrary data.
+	// Each message field is rendered by the template of the same name; fields
+	// without a template are left empty. The rendered tags are split on commas,
+	// and the rendered priority is parsed with ParsePriority
+	Template struct {
+		tmpl *template.Template
+	}
+
+	// TemplateFields holds the template strings of the message fields
+	TemplateFields struct {
+		Title    string
+		Message  string
+		Tags     string
+		Priority string
+		Click    string
+	}
+)
+
+// NewTemplate parses the given template strings
+func NewTemplate(fields TemplateFields) (*Template, error) {
+	t := template.New("").Funcs(TemplateFuncs())
+	for name, text := range map[string]string{
+		TitleTemplate:    fields.Title,
+		MessageTemplate:  fields.Message,
+		TagsTemplate:     fields.Tags,
+		PriorityTemplate: fields.Priority,
+		ClickTemplate:    fields.Click,
+	} {
+		if text == "" {
+			continue
+		}
+
+		if _, err := t.New(name).Parse(text); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Template{tmpl: t}, nil
+}
+
+// ParseTemplateFile parses a template file defining the message fields with
+// define actions, e.g. {{define "title"}}Deploy of {{.Service}}{{end}}
+func ParseTemplateFile(path string) (*Template, error) {
+	t, err := template.New("").Funcs(TemplateFuncs()).ParseFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Template{tmpl: t}, nil
+}
+
+// ParseTemplateFS is like ParseTemplateFile but reads from the given file system
+func ParseTemplateFS(fsys fs.FS, patterns ...string) (*Template, error) {
+	t, err := template.New("").Funcs(TemplateFuncs()).ParseFS(fsys, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Template{tmpl: t}, nil
+}
+
+// Execute renders a message from the given data. The topic of the returned
+// message is left empty
+func (t *Template) Execute(data any) (*Message, error) {
+	var (
+		m   = &Message{}
+		err error
+	)
+
+	if m.Title, err = t.render(TitleTemplate, data); err != nil {
+		return nil, err
+	}
+
+	if m.Message, err = t.render(MessageTemplate, data); err != nil {
+		return nil, err
+	}
+
+	tags, err := t.render(TagsTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+
+	priority, err := t.render(PriorityTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	if priority = strings.TrimSpace(priority); priority != "" {
+		if m.Priority, err = ParsePriority(priority); err != nil {
+			return nil, err
+		}
+	}
+
+	click, err := t.render(ClickTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	if click = strings.TrimSpace(click); click != "" {
+		if m.ClickURL, err = url.Parse(click); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// render executes the named template, returning an empty string if it is not defined
+func (t *Template) render(name string, data any) (string, error) {
+	tmpl := t.tmpl.Lookup(name)
+	if tmpl == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// TemplateFuncs returns the helper functions available in templates:
+//
+//	emoji STATUS        tag for a status such as ok, warning, error or critical
+//	duration VALUE      human readable duration of a time.Duration or seconds
+//	since TIME          human readable duration since a time.Time
+//	truncate N STRING   STRING shortened to at most N characters
+//	join SEP LIST       elements of LIST joined by SEP
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"emoji":    emojiTag,
+		"duration": formatDuration,
+		"since":    func(t time.Time) (string, error) { return formatDuration(time.Since(t)) },
+		"truncate": truncate,
+		"join":     join,
+	}
+}
+
+// emojiTag returns the emoji tag for a status
+func emojiTag(status string) string {
+	switch strings.ToLower(status) {
+	case "ok", "success", "succeeded", "passed", "resolved":
+		return emojis.White_check_mark
+	case "warn", "warning":
+		return emojis.Warning
+	case "error", "failure", "failed":
+		return emojis.X
+	case "critical", "fatal", "alert":
+		return emojis.Rotating_light
+	case "info", "notice":
+		return emojis.Information_source
+	case "release", "deployed":
+		return emojis.Tada
+	}
+
+	return emojis.Question
+}
+
+// formatDuration formats a time.Duration, or a number of seconds, rounded to
+// the largest two units, e.g. 2d 3h or 1m 5s
+func formatDuration(v any) (string, error) {
+	var d time.Duration
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if dv, ok := v.(time.Duration); ok {
+			d = dv
+		} else {
+			d = time.Duration(rv.Int()) * time.Second
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		d = time.Duration(rv.Uint()) * time.Second
+	case reflect.Float32, reflect.Float64:
+		d = time.Duration(math.Round(rv.Float() * float64(time.Second)))
+	default:
+		return "", fmt.Errorf("duration: unsupported value of type %T", v)
+	}
+
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Second {
+		return d.Round(time.Millisecond).String(), nil
+	}
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	var parts []string
+	for i, u := range units {
+		if d < u.size {
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf("%d%s", d/u.size, u.suffix))
+		if i+1 < len(units) {
+			if n := d % u.size / units[i+1].size; n > 0 {
+				parts = append(parts, fmt.Sprintf("%d%s", n, units[i+1].suffix))
+			}
+		}
+		break
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// truncate shortens s to at most n characters, ending it with an ellipsis if it was cut
+func truncate(n int, s string) string {
+	if n <= 0 {
+		return ""
+	}
+
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
+// join joins the elements of a slice with the given separator
+func join(sep string, list any) (string, error) {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: unsupported value of type %T", list)
+	}
+
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+
+	return strings.Join(parts, sep), nil
+}
@@ -0,0 +1,135 @@
+package ntfy
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/qubebit/ntfy-go/pkg/emojis"
+)
+
+func TestTemplateExecute(t *testing.T) {
+	type deploy struct {
+		Service  string
+		Status   string
+		Took     time.Duration
+		Hosts    []string
+		Priority string
+		URL      string
+		Log      string
+	}
+
+	testCases := []struct {
+		name        string
+		fields      TemplateFields
+		data        any
+		expected    *Message
+		expectedErr error
+	}{
+		{
+			name:     "No Templates",
+			expected: &Message{},
+		},
+		{
+			name: "All Fields",
+			fields: TemplateFields{
+				Title:    "Deploy of {{.Service}} {{.Status}}",
+				Message:  "Took {{duration .Took}} on {{join \", \" .Hosts}}\n{{truncate 10 .Log}}",
+				Tags:     "{{emoji .Status}}, deploy ,{{.Service}}",
+				Priority: "{{.Priority}}",
+				Click:    "{{.URL}}",
+			},
+			data: deploy{
+				Service:  "api",
+				Status:   "failed",
+				Took:     90 * time.Second,
+				Hosts:    []string{"web1", "web2"},
+				Priority: "high",
+				URL:      "https://ci.example.com/42",
+				Log:      "panic: runtime error",
+			},
+			expected: &Message{
+				Title:    "Deploy of api failed",
+				Message:  "Took 1m 30s on web1, web2\npanic: ru…",
+				Tags:     []string{emojis.X, "deploy", "api"},
+				Priority: High,
+				ClickURL: &url.URL{Scheme: "https", Host: "ci.example.com", Path: "/42"},
+			},
+		},
+		{
+			name:        "Invalid Priority",
+			fields:      TemplateFields{Priority: "{{.}}"},
+			data:        "loud",
+			expectedErr: ErrInvalidPriority,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tc.fields)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			actual, err := tmpl.Execute(tc.data)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.tmpl")
+	data := `{{define "title"}}{{.}} deployed{{end}}{{define "tags"}}{{emoji "release"}}{{end}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tmpl, err := ParseTemplateFile(path)
+	if err != nil {
+		t.Fatalf("ParseTemplateFile() error = %v", err)
+	}
+
+	actual, err := tmpl.Execute("api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Message{Title: "api deployed", Tags: []string{emojis.Tada}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestParseTemplateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"alert.tmpl": {Data: []byte(`{{define "title"}}{{.Name}} is down{{end}}` +
+			`{{define "message"}}Down for {{duration .Seconds}}{{end}}` +
+			`{{define "priority"}}urgent{{end}}`)},
+	}
+
+	tmpl, err := ParseTemplateFS(fsys, "alert.tmpl")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	actual, err := tmpl.Execute(map[string]any{"Name": "db", "Seconds": 2*86400 + 3*3600 + 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Message{Title: "db is down", Message: "Down for 2d 3h", Priority: Max}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,197 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrMissingToken is returned when the client is configured with an empty
+// access token, or an operation requires it to be configured with one
+var ErrMissingToken = errors.New("missing access token")
+
+// ErrInvalidLifetime is returned when a token lifetime is not longer than
+// the refresh margin
+var ErrInvalidLifetime = errors.New("token lifetime must be longer than the refresh margin")
+
+type (
+	// TokenOpts are the properties of an access token to create or update
+	TokenOpts struct {
+		Label   string
+		Expires time.Time // Zero means the token never expires when creating, and is left unchanged when updating
+	}
+
+	// TokenRefresher periodically extends the access token the client is
+	// configured with, so that short-lived tokens stay valid while in use
+	TokenRefresher struct {
+		client   *Client
+		lifetime time.Duration
+		options  *TokenRefresherOptions
+	}
+
+	TokenRefresherOptions struct {
+		Margin  time.Duration      // How long before expiry the token is extended; half the lifetime by default
+		Backoff Backoff            // Delay between attempts after a failed refresh
+		OnError func(err error)    // Called when a refresh fails
+		OnRenew func(*AccessToken) // Called after the token was extended
+	}
+
+	TokenRefresherOption func(*TokenRefresherOptions)
+
+	tokenRequest struct {
+		Token   string `json:"token,omitempty"`
+		Label   string `json:"label,omitempty"`
+		Expires int64  `json:"expires,omitempty"`
+	}
+)
+
+func WithRefreshMargin(d time.Duration) TokenRefresherOption {
+	return func(o *TokenRefresherOptions) {
+		o.Margin = d
+	}
+}
+
+func WithRefreshBackoff(b Backoff) TokenRefresherOption {
+	return func(o *TokenRefresherOptions) {
+		o.Backoff = b
+	}
+}
+
+func WithOnRefreshError(fn func(err error)) TokenRefresherOption {
+	return func(o *TokenRefresherOptions) {
+		o.OnError = fn
+	}
+}
+
+func WithOnRenew(fn func(*AccessToken)) TokenRefresherOption {
+	return func(o *TokenRefresherOptions) {
+		o.OnRenew = fn
+	}
+}
+
+// newTokenRequest returns the wire representation of the token options
+func newTokenRequest(token string, opts *TokenOpts) *tokenRequest {
+	req := &tokenRequest{Token: token}
+	if opts != nil {
+		req.Label = opts.Label
+		if !opts.Expires.IsZero() {
+			req.Expires = opts.Expires.Unix()
+		}
+	}
+
+	return req
+}
+
+// CreateToken creates an access token for the authenticated user
+func (c *Client) CreateToken(ctx context.Context, opts *TokenOpts) (*AccessToken, error) {
+	var token AccessToken
+	if err := c.doJSON(ctx, http.MethodPost, c.endpoint("v1", "account", "token"), newTokenRequest("", opts), &token, nil); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Tokens returns the access tokens of the authenticated user
+func (c *Client) Tokens(ctx context.Context) ([]AccessToken, error) {
+	account, err := c.Account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Tokens, nil
+}
+
+// UpdateToken changes the label of the given access token, or extends it
+// by setting a later expiry time
+func (c *Client) UpdateToken(ctx context.Context, token string, opts *TokenOpts) (*AccessToken, error) {
+	if err := c.validator.Var(token, "required"); err != nil {
+		return nil, err
+	}
+
+	var updated AccessToken
+	if err := c.doJSON(ctx, http.MethodPatch, c.endpoint("v1", "account", "token"), newTokenRequest(token, opts), &updated, nil); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteToken deletes the given access token
+func (c *Client) DeleteToken(ctx context.Context, token string) error {
+	if err := c.validator.Var(token, "required"); err != nil {
+		return err
+	}
+
+	headers := http.Header{"X-Token": {token}}
+	return c.doJSON(ctx, http.MethodDelete, c.endpoint("v1", "account", "token"), nil, nil, headers)
+}
+
+// NewTokenRefresher creates a refresher setting the expiry of the client
+// token to lifetime from now on every refresh. The client must be
+// configured with WithToken
+func NewTokenRefresher(client *Client, lifetime time.Duration, options ...TokenRefresherOption) (*TokenRefresher, error) {
+	if client.auth == nil || !client.auth.bearer {
+		return nil, ErrMissingToken
+	}
+
+	o := &TokenRefresherOptions{
+		Margin:  lifetime / 2,
+		Backoff: DefaultBackoff,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	if lifetime <= 0 || o.Margin < 0 || o.Margin >= lifetime {
+		return nil, ErrInvalidLifetime
+	}
+
+	return &TokenRefresher{client: client, lifetime: lifetime, options: o}, nil
+}
+
+// Run extends the token immediately and then every time it is about to
+// expire, until the context is cancelled. Failed refreshes are retried with
+// the configured backoff. It always returns the context error
+func (tr *TokenRefresher) Run(ctx context.Context) error {
+	interval := tr.lifetime - tr.options.Margin
+
+	for attempt := 0; ; {
+		delay := interval
+		if err := tr.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if tr.options.OnError != nil {
+				tr.options.OnError(err)
+			}
+
+			delay = min(tr.options.Backoff.Delay(attempt), interval)
+			attempt++
+		} else {
+			attempt = 0
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// Refresh extends the token once
+func (tr *TokenRefresher) Refresh(ctx context.Context) error {
+	token, err := tr.client.UpdateToken(ctx, tr.client.auth.token, &TokenOpts{
+		Expires: time.Now().Add(tr.lifetime),
+	})
+	if err != nil {
+		return err
+	}
+
+	if tr.options.OnRenew != nil {
+		tr.options.OnRenew(token)
+	}
+
+	return nil
+}
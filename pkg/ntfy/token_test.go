@@ -0,0 +1,217 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenRequests(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name     string
+		call     func(c *Client) error
+		expected recordedRequest
+	}{
+		{
+			name: "Create",
+			call: func(c *Client) error {
+				_, err := c.CreateToken(context.Background(), &TokenOpts{Label: "ci", Expires: expires})
+				return err
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/token",
+				body:   `{"label":"ci","expires":1700000000}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Create Without Expiry",
+			call: func(c *Client) error {
+				_, err := c.CreateToken(context.Background(), nil)
+				return err
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				path:   "/v1/account/token",
+				body:   `{}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Update",
+			call: func(c *Client) error {
+				_, err := c.UpdateToken(context.Background(), "tk_other", &TokenOpts{Expires: expires})
+				return err
+			},
+			expected: recordedRequest{
+				method: http.MethodPatch,
+				path:   "/v1/account/token",
+				body:   `{"token":"tk_other","expires":1700000000}`,
+				auth:   "Bearer tk_client",
+			},
+		},
+		{
+			name: "Delete",
+			call: func(c *Client) error {
+				return c.DeleteToken(context.Background(), "tk_other")
+			},
+			expected: recordedRequest{
+				method: http.MethodDelete,
+				path:   "/v1/account/token",
+				auth:   "Bearer tk_client",
+				token:  "tk_other",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, rec := newRecordingServer(t, http.StatusOK, `{"token":"tk_new"}`)
+
+			c, err := New(WithHost(srv.URL), WithToken("tk_client"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := tc.call(c); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if *rec != tc.expected {
+				t.Errorf("Expected request %+v, got %+v", tc.expected, *rec)
+			}
+		})
+	}
+}
+
+func TestTokens(t *testing.T) {
+	srv, _ := newRecordingServer(t, http.StatusOK, `{"username":"ci","tokens":[{"token":"tk_a","label":"one"},{"token":"tk_b","expires":1700000000}]}`)
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tokens, err := c.Tokens(context.Background())
+	if err != nil {
+		t.Fatalf("Tokens() error = %v", err)
+	}
+
+	if len(tokens) != 2 || tokens[0].Label != "one" || tokens[1].Expires != 1700000000 {
+		t.Errorf("unexpected tokens: %+v", tokens)
+	}
+}
+
+func TestTokenRefresher(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []tokenRequest
+		fail     = true
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fail {
+			fail = false
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var req tokenRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		fmt.Fprintf(w, `{"token":%q,"expires":%d}`, req.Token, req.Expires)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL), WithToken("tk_ci"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var errs, renewals int
+	tr, err := NewTokenRefresher(c, time.Hour,
+		WithRefreshMargin(time.Hour-20*time.Millisecond),
+		WithRefreshBackoff(Backoff{Initial: time.Millisecond, Multiplier: 1}),
+		WithOnRefreshError(func(error) { errs++ }),
+		WithOnRenew(func(*AccessToken) { renewals++ }),
+	)
+	if err != nil {
+		t.Fatalf("NewTokenRefresher() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer cancel()
+
+	if err := tr.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if errs != 1 {
+		t.Errorf("unexpected number of failed refreshes: got = %d, want = 1", errs)
+	}
+	if renewals < 2 || renewals != len(requests) {
+		t.Errorf("unexpected number of renewals: got = %d, requests = %d", renewals, len(requests))
+	}
+	for _, req := range requests {
+		if req.Token != "tk_ci" {
+			t.Errorf("unexpected token: got = %s, want = tk_ci", req.Token)
+		}
+		if until := time.Until(time.Unix(req.Expires, 0)); until < 59*time.Minute {
+			t.Errorf("token extended by %v only", until)
+		}
+	}
+}
+
+func TestNewTokenRefresher(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []Option
+		lifetime    time.Duration
+		expectedErr error
+	}{
+		{
+			name:        "Basic Auth",
+			opts:        []Option{WithBasicAuth("phil", "secret")},
+			lifetime:    time.Hour,
+			expectedErr: ErrMissingToken,
+		},
+		{
+			name:        "Zero Lifetime",
+			opts:        []Option{WithToken("tk_ci")},
+			expectedErr: ErrInvalidLifetime,
+		},
+		{
+			name:     "Token",
+			opts:     []Option{WithToken("tk_ci")},
+			lifetime: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if _, err := NewTokenRefresher(c, tc.lifetime); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
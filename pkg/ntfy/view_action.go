@@ -37,3 +37,29 @@ func (v *ViewAction) MarshalJSON() ([]byte, error) {
 		Clear:  v.Clear,
 	})
 }
+
+func (v *ViewAction) UnmarshalJSON(data []byte) error {
+	var aux viewAction
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	link, err := parseOptionalURL(aux.URL)
+	if err != nil {
+		return err
+	}
+
+	*v = ViewAction{
+		Label: aux.Label,
+		Link:  link,
+		Clear: aux.Clear,
+	}
+
+	return nil
+}
+
+// MarshalText encodes the action in ntfy's short form, e.g.
+// view, Open portal, https://example.com, clear=true
+func (v *ViewAction) MarshalText() ([]byte, error) {
+	return formatAction("view", v.Label, v.Link, nil, v.Clear)
+}
@@ -0,0 +1,59 @@
+package ntfy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// WebhookOpts are the options of a publish request rendered by the server
+// from a JSON payload. Title and Message are Go templates evaluated by the
+// server against the payload, e.g. "{{.action}} on {{.repository.name}}"
+type WebhookOpts struct {
+	Topic   string      `validate:"required"`
+	Payload any         `validate:"required"` // Sent verbatim if []byte or json.RawMessage, JSON encoded otherwise
+	Title   string      // Title template
+	Message string      // Message template
+	Headers http.Header `validate:"-"`
+}
+
+// PublishWebhook publishes a JSON payload to the topic and lets the server
+// format the message with the title and message templates. This allows
+// forwarding third-party webhook payloads as they are
+func (c *Client) PublishWebhook(ctx context.Context, opts *WebhookOpts) (*PublishResult, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	switch p := opts.Payload.(type) {
+	case json.RawMessage:
+		body = p
+	case []byte:
+		body = p
+	default:
+		var err error
+		if body, err = json.Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+
+	u := c.endpoint(opts.Topic)
+	q := u.Query()
+	q.Set("tpl", "yes")
+	if opts.Title != "" {
+		q.Set("t", opts.Title)
+	}
+	if opts.Message != "" {
+		q.Set("m", opts.Message)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body), opts.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.publish(req)
+}
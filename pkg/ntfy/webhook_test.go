@@ -0,0 +1,90 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPublishWebhook(t *testing.T) {
+	testCases := []struct {
+		name         string
+		opts         *WebhookOpts
+		expectedBody string
+		expectedQry  url.Values
+	}{
+		{
+			name: "Raw Payload",
+			opts: &WebhookOpts{
+				Topic:   "alerts",
+				Payload: json.RawMessage(`{"status":"firing","alert":{"name":"HighLoad"}}`),
+				Title:   "{{.alert.name}}",
+				Message: "Status: {{.status}}",
+			},
+			expectedBody: `{"status":"firing","alert":{"name":"HighLoad"}}`,
+			expectedQry:  url.Values{"tpl": {"yes"}, "t": {"{{.alert.name}}"}, "m": {"Status: {{.status}}"}},
+		},
+		{
+			name: "Encoded Payload",
+			opts: &WebhookOpts{
+				Topic:   "alerts",
+				Payload: map[string]string{"status": "resolved"},
+				Message: "{{.status}}",
+			},
+			expectedBody: `{"status":"resolved"}`,
+			expectedQry:  url.Values{"tpl": {"yes"}, "m": {"{{.status}}"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				gotPath  string
+				gotQuery url.Values
+				gotBody  string
+				gotAuth  string
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotQuery = r.URL.Query()
+				gotAuth = r.Header.Get("Authorization")
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+				fmt.Fprintln(w, `{"id":"m1","time":1,"event":"message","topic":"alerts"}`)
+			}))
+			defer srv.Close()
+
+			c, err := New(WithHost(srv.URL), WithToken("tk_abc"))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			res, err := c.PublishWebhook(context.Background(), tc.opts)
+			if err != nil {
+				t.Fatalf("PublishWebhook() error = %v", err)
+			}
+
+			if res.ID != "m1" {
+				t.Errorf("unexpected result ID: got = %s, want = m1", res.ID)
+			}
+			if gotPath != "/alerts" {
+				t.Errorf("unexpected path: got = %s, want = /alerts", gotPath)
+			}
+			if gotBody != tc.expectedBody {
+				t.Errorf("unexpected body: got = %s, want = %s", gotBody, tc.expectedBody)
+			}
+			if gotQuery.Encode() != tc.expectedQry.Encode() {
+				t.Errorf("unexpected query: got = %v, want = %v", gotQuery, tc.expectedQry)
+			}
+			if gotAuth != "Bearer tk_abc" {
+				t.Errorf("unexpected Authorization header: got = %q", gotAuth)
+			}
+		})
+	}
+}
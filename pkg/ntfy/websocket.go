@@ -0,0 +1,307 @@
+package ntfy
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WebSocket frame opcodes as defined in RFC 6455
+const (
+	wsOpContinuation byte = 0x0
+	wsOpText         byte = 0x1
+	wsOpBinary       byte = 0x2
+	wsOpClose        byte = 0x8
+	wsOpPing         byte = 0x9
+	wsOpPong         byte = 0xA
+)
+
+const (
+	wsAcceptGUID      = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxMessageSize  = 1 << 20
+	wsCloseNormal     = 1000
+	wsMaxControlFrame = 125
+)
+
+var (
+	ErrWebSocketHandshake = errors.New("invalid websocket handshake")
+	ErrWebSocketProtocol  = errors.New("websocket protocol error")
+)
+
+// wsConn is a minimal WebSocket connection on top of an upgraded HTTP connection
+type wsConn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	client bool // Client connections mask outgoing frames
+	mu     sync.Mutex
+	once   sync.Once
+}
+
+// SubscribeWebSocket opens a WebSocket connection to the given topics and
+// delivers every event sent by the server on the returned subscription.
+// The connection is upgraded through the client's HTTP transport; any
+// Authorization header is also passed in the auth query parameter, since
+// some gateways drop headers on upgrade requests
+func (c *Client) SubscribeWebSocket(ctx context.Context, opts *SubscribeOpts) (*Subscription, error) {
+	if err := c.validator.Struct(opts); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	u := c.subscribeURL(opts, "ws")
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil, opts.Headers)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	conn, err := c.dialWebSocket(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.close(wsCloseNormal)
+	}()
+
+	return newSubscription(ctx, cancel, func(emit func(*ReceivedMessage) bool) error {
+		for {
+			data, err := conn.readMessage()
+			if err != nil {
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					return ErrSubscriptionClosed
+				}
+				return err
+			}
+
+			var m ReceivedMessage
+			if err := json.Unmarshal(data, &m); err != nil {
+				return err
+			}
+
+			if !emit(&m) {
+				return nil
+			}
+		}
+	}), nil
+}
+
+// dialWebSocket performs the opening handshake for the given request
+func (c *Client) dialWebSocket(req *http.Request) (*wsConn, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	challenge := base64.StdEncoding.EncodeToString(key)
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		q := req.URL.Query()
+		q.Set("auth", authQueryParam(auth))
+		req.URL.RawQuery = q.Encode()
+	}
+
+	req.Header.Del("Content-Type")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", challenge)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok || !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") ||
+		resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(challenge) {
+		resp.Body.Close()
+		return nil, ErrWebSocketHandshake
+	}
+
+	return newWSConn(rwc, true), nil
+}
+
+// authQueryParam encodes an Authorization header value for the auth query parameter
+func authQueryParam(header string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(header))
+}
+
+// wsAcceptKey computes the expected Sec-WebSocket-Accept value for a challenge
+func wsAcceptKey(challenge string) string {
+	h := sha1.Sum([]byte(challenge + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func newWSConn(rwc io.ReadWriteCloser, client bool) *wsConn {
+	return &wsConn{rwc: rwc, br: bufio.NewReader(rwc), client: client}
+}
+
+// readMessage returns the payload of the next data message. Ping frames are
+// answered and fragmented messages are reassembled. A close frame from the
+// peer is acknowledged and reported as io.EOF
+func (ws *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := ws.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case wsOpPing:
+			if err := ws.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			code := wsCloseNormal
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			ws.close(code)
+			return nil, io.EOF
+		case wsOpText, wsOpBinary:
+			if started {
+				return nil, fmt.Errorf("%w: unexpected data frame in fragmented message", ErrWebSocketProtocol)
+			}
+			started = true
+		case wsOpContinuation:
+			if !started {
+				return nil, fmt.Errorf("%w: unexpected continuation frame", ErrWebSocketProtocol)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown opcode %d", ErrWebSocketProtocol, op)
+		}
+
+		if len(msg)+len(payload) > wsMaxMessageSize {
+			return nil, fmt.Errorf("%w: message too large", ErrWebSocketProtocol)
+		}
+		msg = append(msg, payload...)
+
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readFrame reads a single frame, unmasking the payload if needed
+func (ws *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(ws.br, header[:]); err != nil {
+		return
+	}
+
+	fin = header[0]&0x80 != 0
+	op = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(ws.br, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(ws.br, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if length > wsMaxMessageSize {
+		err = fmt.Errorf("%w: frame too large", ErrWebSocketProtocol)
+		return
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(ws.br, mask[:]); err != nil {
+			return
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(ws.br, payload); err != nil {
+		return
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return
+}
+
+// writeFrame writes a single unfragmented frame
+func (ws *wsConn) writeFrame(op byte, payload []byte) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	frame := []byte{0x80 | op}
+
+	var maskBit byte
+	if ws.client {
+		maskBit = 0x80
+	}
+
+	switch n := len(payload); {
+	case n <= wsMaxControlFrame:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+
+	if ws.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	_, err := ws.rwc.Write(frame)
+	return err
+}
+
+// close sends a close frame with the given status code and releases the connection
+func (ws *wsConn) close(code int) {
+	ws.once.Do(func() {
+		ws.writeFrame(wsOpClose, binary.BigEndian.AppendUint16(nil, uint16(code)))
+		ws.rwc.Close()
+	})
+}
@@ -0,0 +1,161 @@
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// serveWebSocket upgrades the request and hands the server side of the connection to fn
+func serveWebSocket(t *testing.T, fn func(r *http.Request, ws *wsConn)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+			t.Errorf("unexpected upgrade headers: %v", r.Header)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+		rw.WriteString("Upgrade: websocket\r\nConnection: Upgrade\r\n")
+		rw.WriteString("Sec-WebSocket-Accept: " + wsAcceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+		rw.Flush()
+
+		fn(r, newWSConn(conn, false))
+	}
+}
+
+func TestSubscribeWebSocket(t *testing.T) {
+	var (
+		gotPath string
+		gotAuth string
+		gotPong []byte
+		gotCode int
+		done    = make(chan struct{})
+	)
+
+	srv := httptest.NewServer(serveWebSocket(t, func(r *http.Request, ws *wsConn) {
+		defer close(done)
+
+		gotPath = r.URL.Path
+		gotAuth = r.URL.Query().Get("auth")
+
+		ws.writeFrame(wsOpText, []byte(`{"id":"o1","time":1,"event":"open","topic":"t1,t2"}`))
+		ws.writeFrame(wsOpPing, []byte("ping"))
+
+		_, op, payload, err := ws.readFrame()
+		if err != nil || op != wsOpPong {
+			t.Errorf("expected pong frame, got op = %d, err = %v", op, err)
+		}
+		gotPong = payload
+
+		// Fragmented message split in a text and a continuation frame
+		first, rest := `{"id":"m1"`, `,"event":"message","topic":"t1","message":"x"}`
+		ws.rwc.Write(append([]byte{wsOpText, byte(len(first))}, first...))
+		ws.rwc.Write(append([]byte{0x80 | wsOpContinuation, byte(len(rest))}, rest...))
+
+		_, op, payload, err = ws.readFrame()
+		if err != nil || op != wsOpClose || len(payload) < 2 {
+			t.Errorf("expected close frame, got op = %d, err = %v", op, err)
+			return
+		}
+		gotCode = int(payload[0])<<8 | int(payload[1])
+	}))
+	defer srv.Close()
+
+	headers := http.Header{"Authorization": []string{"Bearer tk_test"}}
+	c, err := New(WithHost(srv.URL), WithHeaders(headers))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := c.SubscribeWebSocket(ctx, &SubscribeOpts{Topics: []string{"t1", "t2"}})
+	if err != nil {
+		t.Fatalf("SubscribeWebSocket() error = %v", err)
+	}
+
+	var got []*ReceivedMessage
+	for m := range sub.Messages() {
+		got = append(got, m)
+		if len(got) == 2 {
+			sub.Close()
+		}
+	}
+
+	want := []*ReceivedMessage{
+		{ID: "o1", Time: 1, Event: OpenEvent, Topic: "t1,t2"},
+		{ID: "m1", Event: MessageEvent, Topic: "t1", Message: "x"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected messages:\n got  = %+v\n want = %+v", got, want)
+	}
+
+	<-done
+
+	if gotPath != "/t1,t2/ws" {
+		t.Errorf("unexpected path: got = %s, want = /t1,t2/ws", gotPath)
+	}
+	if want := authQueryParam("Bearer tk_test"); gotAuth != want {
+		t.Errorf("unexpected auth param: got = %s, want = %s", gotAuth, want)
+	}
+	if string(gotPong) != "ping" {
+		t.Errorf("unexpected pong payload: got = %q, want = %q", gotPong, "ping")
+	}
+	if gotCode != wsCloseNormal {
+		t.Errorf("unexpected close code: got = %d, want = %d", gotCode, wsCloseNormal)
+	}
+}
+
+func TestSubscribeWebSocketServerClose(t *testing.T) {
+	srv := httptest.NewServer(serveWebSocket(t, func(r *http.Request, ws *wsConn) {
+		ws.writeFrame(wsOpClose, []byte{0x03, 0xE9})
+		ws.readFrame()
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	sub, err := c.SubscribeWebSocket(context.Background(), &SubscribeOpts{Topics: []string{"t1"}})
+	if err != nil {
+		t.Fatalf("SubscribeWebSocket() error = %v", err)
+	}
+
+	for range sub.Messages() {
+	}
+
+	if err := sub.Err(); !errors.Is(err, ErrSubscriptionClosed) {
+		t.Errorf("unexpected error: got = %v, want = %v", err, ErrSubscriptionClosed)
+	}
+}
+
+func TestSubscribeWebSocketHandshakeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.SubscribeWebSocket(context.Background(), &SubscribeOpts{Topics: []string{"t1"}}); err == nil {
+		t.Error("expected error for rejected upgrade")
+	}
+}